@@ -13,11 +13,16 @@ type baseRequester interface {
 	customHeaders() map[httpHeader]string
 	formFields() map[formField]string
 	formDocuments() map[string]document.Document
+	recordUpload(stats UploadStats)
+	progressFunc() ProgressFunc
 }
 
 type baseRequest struct {
 	headers map[httpHeader]string
 	fields  map[formField]string
+
+	uploads  uploadRecorder
+	progress ProgressFunc
 }
 
 func newBaseRequest() *baseRequest {
@@ -35,6 +40,25 @@ func (br *baseRequest) formFields() map[formField]string {
 	return br.fields
 }
 
+func (br *baseRequest) recordUpload(stats UploadStats) {
+	br.uploads.set(stats)
+}
+
+// UploadStats returns the total size of the request body and the size of each document
+// uploaded by the last successful send of the request.
+func (br *baseRequest) UploadStats() UploadStats {
+	return br.uploads.get()
+}
+
+func (br *baseRequest) progressFunc() ProgressFunc {
+	return br.progress
+}
+
+// OnProgress reports the progress of the request to fn, in addition to the function set with WithProgress.
+func (br *baseRequest) OnProgress(fn ProgressFunc) {
+	br.progress = fn
+}
+
 // OutputFilename overrides the default UUID output filename.
 //
 // NOTE: Gotenberg adds the file extension automatically; you don't have to set it.
@@ -70,6 +94,17 @@ func (br *baseRequest) SetWebhookErrorMethod(method string) {
 	br.headers[headerWebhookErrorMethod] = ensureWebhookMethod(method)
 }
 
+// UseWebhookEvents sets the URL to which Gotenberg sends the lifecycle events of the request, such as
+// started and completed. Use WebhookEventsURL to build it for a WebhookHandler.
+func (br *baseRequest) UseWebhookEvents(eventsURL string) {
+	br.headers[headerWebhookEventsURL] = eventsURL
+}
+
+// SetWebhookEventsMethod overrides the default HTTP method that Gotenberg will use to call the events URL.
+func (br *baseRequest) SetWebhookEventsMethod(method string) {
+	br.headers[headerWebhookEventsMethod] = ensureWebhookMethod(method)
+}
+
 // SetWebhookExtraHeaders sets the extra HTTP headers that Gotenberg will send alongside the
 // request to the webhook and error webhook.
 func (br *baseRequest) SetWebhookExtraHeaders(headers map[string]string) error {
@@ -0,0 +1,231 @@
+package gotenberg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	errBatchAborted = errors.New("batch aborted after a failure")
+	errNoBatchDest  = errors.New("batch job has no destination")
+	errNoBatchReq   = errors.New("batch job has no request")
+)
+
+const defaultBatchConcurrency = 4
+
+// BatchJob is a request of a batch and the file its result is stored to.
+type BatchJob struct {
+	Request multipartRequester
+	Dest    string
+}
+
+// BatchStatus is the outcome of a batch job.
+type BatchStatus int
+
+const (
+	// BatchSucceeded means the result was stored to the destination.
+	BatchSucceeded BatchStatus = iota + 1
+	// BatchFailed means the request or the storing failed.
+	BatchFailed
+	// BatchCanceled means the job was not run, or was interrupted, because the context was canceled
+	// or because another job failed in fail-fast mode.
+	BatchCanceled
+)
+
+func (s BatchStatus) String() string {
+	switch s {
+	case BatchSucceeded:
+		return "succeeded"
+	case BatchFailed:
+		return "failed"
+	case BatchCanceled:
+		return "canceled"
+	default:
+		return fmt.Sprintf("BatchStatus(%d)", int(s))
+	}
+}
+
+// BatchResult is the outcome of a batch job.
+type BatchResult struct {
+	// Index is the position of the job in the batch.
+	Index  int
+	Job    BatchJob
+	Status BatchStatus
+	// Err is set unless the job succeeded.
+	Err error
+	// Start is zero if the job never started.
+	Start    time.Time
+	Duration time.Duration
+}
+
+// BatchReport summarizes a batch.
+type BatchReport struct {
+	// Results holds the result of every job, in the order of the batch.
+	Results   []BatchResult
+	Succeeded int
+	Failed    int
+	Canceled  int
+	Duration  time.Duration
+}
+
+// Err joins the errors of the failed jobs, or returns nil if none failed.
+func (r *BatchReport) Err() error {
+	var errs []error
+	for _, res := range r.Results {
+		if res.Status == BatchFailed {
+			errs = append(errs, fmt.Errorf("job %d (%s): %w", res.Index, res.Job.Dest, res.Err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Batch stores the results of many requests with bounded concurrency on one Client.
+type Batch struct {
+	client      *Client
+	jobs        []BatchJob
+	concurrency int
+	failFast    bool
+}
+
+// NewBatch creates a batch of jobs run by c, 4 at a time, which goes on when a job fails.
+func NewBatch(c *Client, jobs ...BatchJob) *Batch {
+	return &Batch{client: c, jobs: jobs, concurrency: defaultBatchConcurrency}
+}
+
+// Add adds a job storing the result of req to dest.
+func (b *Batch) Add(req multipartRequester, dest string) {
+	b.jobs = append(b.jobs, BatchJob{Request: req, Dest: dest})
+}
+
+// Concurrency sets how many jobs run at the same time.
+func (b *Batch) Concurrency(n int) {
+	b.concurrency = max(n, 1)
+}
+
+// FailFast stops the batch at the first failure: running jobs are interrupted and queued jobs
+// are not started, both being reported as canceled.
+func (b *Batch) FailFast() {
+	b.failFast = true
+}
+
+// Stream runs the batch and sends the result of every job on the returned channel as it completes.
+// The channel is closed once every job is reported, and must be drained. Canceling ctx stops the
+// queued jobs, which are reported as canceled.
+func (b *Batch) Stream(ctx context.Context) <-chan BatchResult {
+	results := make(chan BatchResult)
+	queue := make(chan int)
+
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	var wg sync.WaitGroup
+	for range min(b.concurrency, max(len(b.jobs), 1)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range queue {
+				res := b.run(ctx, i)
+				if res.Status == BatchFailed && b.failFast {
+					cancel(errBatchAborted)
+				}
+
+				results <- res
+			}
+		}()
+	}
+
+	go func() {
+		defer close(results)
+		defer cancel(nil)
+
+		next := 0
+	enqueue:
+		for ; next < len(b.jobs); next++ {
+			select {
+			case queue <- next:
+			case <-ctx.Done():
+				break enqueue
+			}
+		}
+		close(queue)
+
+		wg.Wait()
+
+		for ; next < len(b.jobs); next++ {
+			results <- BatchResult{
+				Index:  next,
+				Job:    b.jobs[next],
+				Status: BatchCanceled,
+				Err:    context.Cause(ctx),
+			}
+		}
+	}()
+
+	return results
+}
+
+// Run runs the batch and returns its report once every job is done. The error of the report is
+// returned too, so that callers which only care about success can check it alone.
+func (b *Batch) Run(ctx context.Context) (*BatchReport, error) {
+	start := time.Now()
+	report := &BatchReport{Results: make([]BatchResult, len(b.jobs))}
+
+	for res := range b.Stream(ctx) {
+		report.Results[res.Index] = res
+
+		switch res.Status {
+		case BatchSucceeded:
+			report.Succeeded++
+		case BatchFailed:
+			report.Failed++
+		case BatchCanceled:
+			report.Canceled++
+		}
+	}
+
+	report.Duration = time.Since(start)
+
+	return report, report.Err()
+}
+
+func (b *Batch) run(ctx context.Context, i int) BatchResult {
+	res := BatchResult{Index: i, Job: b.jobs[i]}
+
+	if ctx.Err() != nil {
+		res.Status, res.Err = BatchCanceled, context.Cause(ctx)
+
+		return res
+	}
+
+	res.Start = time.Now()
+	err := b.store(ctx, res.Job)
+	res.Duration = time.Since(res.Start)
+
+	switch {
+	case err == nil:
+		res.Status = BatchSucceeded
+	case ctx.Err() != nil:
+		res.Status, res.Err = BatchCanceled, fmt.Errorf("%w: %w", context.Cause(ctx), err)
+	default:
+		res.Status, res.Err = BatchFailed, err
+	}
+
+	return res
+}
+
+func (b *Batch) store(ctx context.Context, job BatchJob) error {
+	if job.Request == nil {
+		return errNoBatchReq
+	}
+
+	if job.Dest == "" {
+		return errNoBatchDest
+	}
+
+	return b.client.store(ctx, job.Request, job.Dest)
+}
@@ -0,0 +1,161 @@
+package gotenberg
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+	"github.com/starwalkn/gotenberg-go-client/v8/gotenbergtest"
+)
+
+func newBatchClient(t *testing.T, delay time.Duration) (*Client, *gotenbergtest.Server) {
+	t.Helper()
+
+	srv := gotenbergtest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.SetDelay(delay)
+
+	c, err := NewClient(srv.URL, srv.Client())
+	require.NoError(t, err)
+
+	return c, srv
+}
+
+func newBatchJob(t *testing.T, dir, name string) BatchJob {
+	t.Helper()
+
+	doc, err := document.FromString(name+".txt", "report")
+	require.NoError(t, err)
+
+	return BatchJob{Request: NewLibreOfficeRequest(doc), Dest: filepath.Join(dir, name+".pdf")}
+}
+
+// maxOverlap returns the highest number of jobs which ran at the same time.
+func maxOverlap(results []BatchResult) int {
+	peak := 0
+
+	for _, res := range results {
+		running := 0
+		for _, other := range results {
+			if !other.Start.After(res.Start) && other.Start.Add(other.Duration).After(res.Start) {
+				running++
+			}
+		}
+
+		peak = max(peak, running)
+	}
+
+	return peak
+}
+
+func TestBatchRun(t *testing.T) {
+	c, srv := newBatchClient(t, 20*time.Millisecond)
+	srv.FailNext(1, http.StatusBadRequest)
+
+	dir := t.TempDir()
+	batch := NewBatch(c)
+	batch.Concurrency(2)
+
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		job := newBatchJob(t, dir, name)
+		batch.Add(job.Request, job.Dest)
+	}
+
+	report, err := batch.Run(context.Background())
+	require.ErrorIs(t, err, errGenerationFailed)
+
+	assert.Equal(t, 4, report.Succeeded)
+	assert.Equal(t, 1, report.Failed)
+	assert.Zero(t, report.Canceled)
+	assert.Len(t, srv.Requests(), 5)
+	require.Len(t, report.Results, 5)
+	assert.LessOrEqual(t, maxOverlap(report.Results), 2)
+
+	for i, res := range report.Results {
+		assert.Equal(t, i, res.Index)
+		assert.Positive(t, res.Duration)
+
+		if res.Status == BatchFailed {
+			assert.ErrorContains(t, err, res.Job.Dest)
+
+			continue
+		}
+
+		data, err := os.ReadFile(res.Job.Dest)
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+	}
+}
+
+func TestBatchFailFast(t *testing.T) {
+	c, srv := newBatchClient(t, 20*time.Millisecond)
+	srv.FailNext(1, http.StatusBadRequest)
+
+	dir := t.TempDir()
+	jobs := make([]BatchJob, 0, 4)
+	for _, name := range []string{"a", "b", "c", "d"} {
+		jobs = append(jobs, newBatchJob(t, dir, name))
+	}
+
+	batch := NewBatch(c, jobs...)
+	batch.Concurrency(1)
+	batch.FailFast()
+
+	report, err := batch.Run(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, 3, report.Canceled)
+	assert.Equal(t, BatchFailed, report.Results[0].Status)
+	assert.Len(t, srv.Requests(), 1)
+
+	for _, res := range report.Results[1:] {
+		assert.Equal(t, BatchCanceled, res.Status)
+		require.ErrorIs(t, res.Err, errBatchAborted)
+		assert.True(t, res.Start.IsZero())
+	}
+}
+
+func TestBatchStreamCancel(t *testing.T) {
+	c, _ := newBatchClient(t, time.Second)
+
+	dir := t.TempDir()
+	batch := NewBatch(c)
+	batch.Concurrency(2)
+
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		job := newBatchJob(t, dir, name)
+		batch.Add(job.Request, job.Dest)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	var indexes []int
+
+	start := time.Now()
+	for res := range batch.Stream(ctx) {
+		assert.Equal(t, BatchCanceled, res.Status)
+		require.ErrorIs(t, res.Err, context.Canceled)
+
+		indexes = append(indexes, res.Index)
+	}
+
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5}, indexes)
+}
+
+func TestBatchInvalidJob(t *testing.T) {
+	c, _ := newBatchClient(t, 0)
+
+	report, err := NewBatch(c, BatchJob{Dest: "out.pdf"}).Run(context.Background())
+	require.ErrorIs(t, err, errNoBatchReq)
+	assert.Equal(t, 1, report.Failed)
+}
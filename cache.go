@@ -0,0 +1,358 @@
+package gotenberg
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+// CacheEntry is a successful reply of Gotenberg, as kept by a CacheStore.
+type CacheEntry struct {
+	// Header holds the headers describing the body, e.g. Content-Type and Content-Disposition.
+	Header http.Header
+	Body   []byte
+}
+
+// CacheStore holds the results of requests by key. Implementations must be safe for concurrent use.
+// A cache is best effort: stores which fail to read or write an entry should report a miss or drop it.
+type CacheStore interface {
+	// Get returns the entry of key, if any.
+	Get(key string) (*CacheEntry, bool)
+	// Put stores the entry of key.
+	Put(key string, entry *CacheEntry)
+}
+
+// WithCache makes the client reuse the results of identical requests, which then skip Gotenberg entirely.
+// Requests are identical if they have the same endpoint, form fields, headers, except the trace, and
+// document contents.
+//
+// Requests whose result may differ between calls bypass the cache: those with a wait delay, those
+// converting a URL or downloading their files, and those using webhooks. So do requests with documents
+// whose size is unknown until they are read, e.g. from document.FromReader, which can be read only once.
+func WithCache(store CacheStore) ClientOption {
+	return func(c *Client) {
+		c.cache = store
+	}
+}
+
+// cacheHeaders are the headers of a reply kept in the cache.
+// nolint: gochecknoglobals
+var cacheHeaders = []string{"Content-Type", "Content-Disposition"}
+
+// cacheKey returns the key identifying the result of a request, or false if the request must not be cached.
+// With checkContent, the documents are checked as they are read, as they would be when sent.
+func cacheKey(mr multipartRequester, endpoint string, checkContent bool) (string, bool, error) {
+	fields := mr.formFields()
+	if fields[fieldURL] != "" || fields[fieldChromiumWaitDelay] != "" || fields[fieldDownloadFrom] != "" {
+		return "", false, nil
+	}
+
+	headers := mr.customHeaders()
+	for key := range headers {
+		if strings.HasPrefix(string(key), "Gotenberg-Webhook-") {
+			return "", false, nil
+		}
+	}
+
+	docs := mr.formDocuments()
+	for _, doc := range docs {
+		if document.Size(doc) < 0 {
+			return "", false, nil
+		}
+	}
+
+	h := sha256.New()
+	writeKeyPart(h, endpoint)
+
+	writeKeyPart(h, "fields")
+	for _, name := range sortedKeys(fields) {
+		writeKeyPart(h, string(name), fields[name])
+	}
+
+	writeKeyPart(h, "headers")
+	for _, name := range sortedKeys(headers) {
+		if name != headerTrace {
+			writeKeyPart(h, string(name), headers[name])
+		}
+	}
+
+	writeKeyPart(h, "documents")
+	for _, fname := range sortedKeys(docs) {
+		sum, err := documentHash(fname, docs[fname], checkContent)
+		if err != nil {
+			return "", false, err
+		}
+
+		writeKeyPart(h, fname, sum)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), true, nil
+}
+
+// writeKeyPart writes length-prefixed strings, so that no two different sequences hash the same.
+func writeKeyPart(h hash.Hash, parts ...string) {
+	for _, part := range parts {
+		_ = binary.Write(h, binary.BigEndian, uint64(len(part)))
+		_, _ = io.WriteString(h, part)
+	}
+}
+
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+func documentHash(fname string, doc document.Document, checkContent bool) (string, error) {
+	in, err := doc.Reader()
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", doc.Filename(), err)
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	r := bufio.NewReaderSize(in, document.SniffLen)
+	if checkContent {
+		var head []byte
+		if head, err = r.Peek(document.SniffLen); err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("reading %s: %w", doc.Filename(), err)
+		}
+
+		if err = document.CheckContent(fname, head); err != nil {
+			return "", err
+		}
+	}
+
+	h := sha256.New()
+	if _, err = io.Copy(h, r); err != nil {
+		return "", fmt.Errorf("reading %s: %w", doc.Filename(), err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// cachedResponse replays an entry as a reply of Gotenberg.
+func cachedResponse(entry *CacheEntry) *http.Response {
+	return bufferedResponse(http.StatusOK, entry.Header, entry.Body)
+}
+
+// bufferedResponse creates a reply whose body is held in memory.
+func bufferedResponse(status int, header http.Header, body []byte) *http.Response {
+	header = header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+}
+
+// cachingBody stores the body of a reply once it is read to the end.
+type cachingBody struct {
+	io.ReadCloser
+
+	store  CacheStore
+	key    string
+	header http.Header
+	buf    bytes.Buffer
+	done   bool
+}
+
+func newCachingBody(resp *http.Response, store CacheStore, key string) *cachingBody {
+	header := make(http.Header)
+	for _, name := range cacheHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			header.Set(name, value)
+		}
+	}
+
+	return &cachingBody{ReadCloser: resp.Body, store: store, key: key, header: header}
+}
+
+func (b *cachingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.buf.Write(p[:n])
+
+	if err == io.EOF && !b.done {
+		b.done = true
+		b.store.Put(b.key, &CacheEntry{Header: b.header, Body: bytes.Clone(b.buf.Bytes())})
+	}
+
+	return n, err
+}
+
+// MemoryCache is a CacheStore which keeps entries in memory, evicting the least recently used ones
+// beyond its capacity.
+type MemoryCache struct {
+	maxBytes int64
+
+	mu      sync.Mutex
+	size    int64
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type memoryCacheItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewMemoryCache creates a MemoryCache holding up to maxBytes of result bodies.
+func NewMemoryCache(maxBytes int64) *MemoryCache {
+	return &MemoryCache{maxBytes: maxBytes, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+func (mc *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	elem, ok := mc.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	mc.order.MoveToFront(elem)
+	item, _ := elem.Value.(*memoryCacheItem)
+
+	return item.entry, true
+}
+
+func (mc *MemoryCache) Put(key string, entry *CacheEntry) {
+	size := int64(len(entry.Body))
+	if size > mc.maxBytes {
+		return
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if elem, ok := mc.entries[key]; ok {
+		mc.remove(elem)
+	}
+
+	mc.entries[key] = mc.order.PushFront(&memoryCacheItem{key: key, entry: entry})
+	mc.size += size
+
+	for mc.size > mc.maxBytes {
+		mc.remove(mc.order.Back())
+	}
+}
+
+// Len returns the number of entries.
+func (mc *MemoryCache) Len() int {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	return len(mc.entries)
+}
+
+func (mc *MemoryCache) remove(elem *list.Element) {
+	item, _ := mc.order.Remove(elem).(*memoryCacheItem)
+	delete(mc.entries, item.key)
+	mc.size -= int64(len(item.entry.Body))
+}
+
+// DiskCache is a CacheStore which keeps entries as files in a directory. It never evicts entries:
+// remove the files to free space.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache creates a DiskCache in dir, which is created if needed.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("making cache directory %s: %w", dir, err)
+	}
+
+	return &DiskCache{dir: dir}, nil
+}
+
+// An entry is stored as its headers in JSON, on the first line, followed by its body.
+func (dc *DiskCache) path(key string) string {
+	return filepath.Join(dc.dir, key)
+}
+
+func (dc *DiskCache) Get(key string) (*CacheEntry, bool) {
+	data, err := os.ReadFile(dc.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	line, body, ok := bytes.Cut(data, []byte("\n"))
+	if !ok {
+		return nil, false
+	}
+
+	entry := &CacheEntry{Body: body}
+	if err = json.Unmarshal(line, &entry.Header); err != nil {
+		return nil, false
+	}
+
+	return entry, true
+}
+
+func (dc *DiskCache) Put(key string, entry *CacheEntry) {
+	header, err := json.Marshal(entry.Header)
+	if err != nil {
+		return
+	}
+
+	// Write to a temporary file first, so that readers never see a partial entry.
+	tmp, err := os.CreateTemp(dc.dir, key+".*.tmp")
+	if err != nil {
+		return
+	}
+
+	_, err = tmp.Write(append(append(header, '\n'), entry.Body...))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), dc.path(key))
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = CacheStore(new(MemoryCache))
+	_ = CacheStore(new(DiskCache))
+)
@@ -0,0 +1,216 @@
+package gotenberg
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+	"github.com/starwalkn/gotenberg-go-client/v8/gotenbergtest"
+)
+
+func newCacheRequest(t *testing.T, content string) *HTMLRequest {
+	t.Helper()
+
+	index, err := document.FromString("index.html", content)
+	require.NoError(t, err)
+
+	return NewHTMLRequest(index)
+}
+
+func fetchBody(t *testing.T, c *Client, req multipartRequester) string {
+	t.Helper()
+
+	resp, err := c.Send(context.Background(), req)
+	require.NoError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
+
+func TestCache(t *testing.T) {
+	stores := map[string]func(t *testing.T) CacheStore{
+		"Memory": func(*testing.T) CacheStore { return NewMemoryCache(1 << 20) },
+		"Disk": func(t *testing.T) CacheStore {
+			dc, err := NewDiskCache(t.TempDir())
+			require.NoError(t, err)
+
+			return dc
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			srv := gotenbergtest.NewServer()
+			defer srv.Close()
+
+			c, err := NewClient(srv.URL, srv.Client(), WithCache(newStore(t)))
+			require.NoError(t, err)
+
+			first := newCacheRequest(t, "<html>Catalogue</html>")
+			first.Trace("first")
+			body := fetchBody(t, c, first)
+
+			second := newCacheRequest(t, "<html>Catalogue</html>")
+			second.Trace("second")
+
+			resp, err := c.Send(context.Background(), second)
+			require.NoError(t, err)
+			assert.Equal(t, "application/pdf", resp.Header.Get("Content-Type"))
+			assert.NotEmpty(t, resp.Header.Get("Content-Disposition"))
+
+			cached, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, body, string(cached))
+			assert.Len(t, srv.Requests(), 1, "the trace is not part of the key")
+
+			fetchBody(t, c, newCacheRequest(t, "<html>Other catalogue</html>"))
+
+			landscape := newCacheRequest(t, "<html>Catalogue</html>")
+			landscape.Landscape()
+			fetchBody(t, c, landscape)
+
+			assert.Len(t, srv.Requests(), 3)
+		})
+	}
+}
+
+func TestCacheBypass(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, srv.Client(), WithCache(NewMemoryCache(1<<20)))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		req  func() multipartRequester
+	}{
+		{"WaitDelay", func() multipartRequester {
+			req := newCacheRequest(t, "<html>Hello</html>")
+			req.WaitDelay(time.Second)
+
+			return req
+		}},
+		{"URLRequest", func() multipartRequester {
+			return NewURLRequest("https://example.com")
+		}},
+		{"UnknownSize", func() multipartRequester {
+			index, err := document.FromReader("index.html", strings.NewReader("<html>Hello</html>"))
+			require.NoError(t, err)
+
+			return NewHTMLRequest(index)
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv.Reset()
+
+			fetchBody(t, c, tc.req())
+			fetchBody(t, c, tc.req())
+
+			assert.Len(t, srv.Requests(), 2)
+		})
+	}
+}
+
+func TestCacheSkipsFailures(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, srv.Client(), WithCache(NewMemoryCache(1<<20)))
+	require.NoError(t, err)
+
+	srv.FailNext(1, http.StatusServiceUnavailable)
+
+	resp, err := c.Send(context.Background(), newCacheRequest(t, "<html>Hello</html>"))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	fetchBody(t, c, newCacheRequest(t, "<html>Hello</html>"))
+	assert.Len(t, srv.Requests(), 2)
+}
+
+func TestCacheHitChecksRequest(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	store := NewMemoryCache(1 << 20)
+
+	lax, err := NewClient(srv.URL, srv.Client(), WithCache(store), WithoutValidation(), WithoutContentCheck())
+	require.NoError(t, err)
+	c, err := NewClient(srv.URL, srv.Client(), WithCache(store))
+	require.NoError(t, err)
+
+	invalid := newCacheRequest(t, "<html>Hello</html>")
+	invalid.Scale(3)
+	fetchBody(t, lax, invalid)
+
+	_, err = c.Send(context.Background(), invalid)
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+
+	doc, err := document.FromString("report.docx", "not a zip archive")
+	require.NoError(t, err)
+	fetchBody(t, lax, NewLibreOfficeRequest(doc))
+
+	_, err = c.Send(context.Background(), NewLibreOfficeRequest(doc))
+	var mismatch *document.ContentMismatchError
+	require.ErrorAs(t, err, &mismatch)
+
+	assert.Len(t, srv.Requests(), 2)
+}
+
+func TestCacheHitUploadStats(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, srv.Client(), WithCache(NewMemoryCache(1<<20)))
+	require.NoError(t, err)
+
+	req := newCacheRequest(t, "<html>Hello</html>")
+	fetchBody(t, c, req)
+	assert.Positive(t, req.UploadStats().Total)
+
+	fetchBody(t, c, req)
+	assert.Zero(t, req.UploadStats().Total)
+	assert.Empty(t, req.UploadStats().Documents)
+	assert.Len(t, srv.Requests(), 1)
+}
+
+func TestMemoryCacheEviction(t *testing.T) {
+	mc := NewMemoryCache(10)
+
+	mc.Put("a", &CacheEntry{Body: []byte("aaaa")})
+	mc.Put("b", &CacheEntry{Body: []byte("bbbb")})
+
+	_, ok := mc.Get("a")
+	require.True(t, ok)
+
+	mc.Put("c", &CacheEntry{Body: []byte("cccc")})
+
+	_, ok = mc.Get("b")
+	assert.False(t, ok, "b is the least recently used")
+	_, ok = mc.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, mc.Len())
+
+	mc.Put("d", &CacheEntry{Body: []byte("too large for the cache")})
+	_, ok = mc.Get("d")
+	assert.False(t, ok)
+}
@@ -3,6 +3,7 @@ package gotenberg
 import (
 	"encoding/json"
 	"fmt"
+	"image"
 	"strconv"
 	"time"
 
@@ -13,11 +14,14 @@ type chromiumRequest struct {
 	header document.Document
 	footer document.Document
 
+	// clipRect is applied by Client.ScreenshotImage.
+	clipRect *image.Rectangle
+
 	*baseRequest
 }
 
 func newChromiumRequest() *chromiumRequest {
-	return &chromiumRequest{nil, nil, newBaseRequest()}
+	return &chromiumRequest{baseRequest: newBaseRequest()}
 }
 
 // WaitDelay sets the duration (i.e., "1s", "2ms", etc.) to wait when loading an
@@ -179,6 +183,12 @@ func (req *chromiumRequest) NativePageRanges(ranges string) {
 	req.fields[fieldChromiumNativePageRanges] = ranges
 }
 
+// NativePageRangesOf sets the page ranges to print, e.g., NativePageRangesOf(Range(1, 5), Page(8)).
+// No ranges means all pages. Valid ranges are normalised, invalid ones are reported by Validate.
+func (req *chromiumRequest) NativePageRangesOf(ranges ...PageRange) {
+	req.fields[fieldChromiumNativePageRanges] = pageRangesField(ranges)
+}
+
 // GenerateDocumentOutline embeds the document outline into the PDF.
 func (req *chromiumRequest) GenerateDocumentOutline() {
 	req.fields[fieldChromiumGenerateDocumentOutline] = strconv.FormatBool(true)
@@ -224,6 +234,25 @@ func (req *chromiumRequest) ScreenshotClip() {
 	req.fields[fieldScreenshotClip] = strconv.FormatBool(true)
 }
 
+// ScreenshotClipRect clips the screenshot to a rectangle of the page, in pixels. Gotenberg can only clip to
+// the device dimensions, so the device is sized to contain the rectangle, overriding ScreenshotWidth and
+// ScreenshotHeight, and ScreenshotImage crops the result. Gotenberg has no clipping to an element selector.
+//
+// The rectangle is only applied by ScreenshotImage, and for the PNG and JPEG formats. Screenshot and
+// StoreScreenshot reject requests with a rectangle.
+func (req *chromiumRequest) ScreenshotClipRect(rect image.Rectangle) {
+	rect = rect.Canon()
+
+	req.clipRect = &rect
+	req.ScreenshotWidth(float64(rect.Max.X))
+	req.ScreenshotHeight(float64(rect.Max.Y))
+	req.ScreenshotClip()
+}
+
+func (req *chromiumRequest) screenshotClipRect() *image.Rectangle {
+	return req.clipRect
+}
+
 // ScreenshotQuality sets the compression quality from range 0 to 100 (jpeg only).
 func (req *chromiumRequest) ScreenshotQuality(quality int) {
 	req.fields[fieldScreenshotQuality] = strconv.Itoa(quality)
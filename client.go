@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 )
 
 var (
@@ -21,18 +23,56 @@ var (
 // multipartRequester is a type for sending form fields and form files (documents) to the Gotenberg API.
 type multipartRequester interface {
 	endpoint() string
+	Validate() error
 
 	baseRequester
 }
 
+// Requester is implemented by the requests of this package, e.g. to build them in functions such as
+// pipeline steps. It cannot be implemented outside of it.
+type Requester interface {
+	multipartRequester
+}
+
 // Client facilitates interacting with the Gotenberg API.
 type Client struct {
-	hostname   string
-	httpClient *http.Client
+	hostname         string
+	httpClient       *http.Client
+	skipValidation   bool
+	skipContentCheck bool
+
+	documentSizeLimit int64
+	requestSizeLimit  int64
+
+	progress ProgressFunc
+	jobs     *Jobs
+
+	webhookSigner *webhookSigner
+
+	cache   CacheStore
+	flights *flightGroup
+}
+
+// ClientOption configures optional behaviour of a Client.
+type ClientOption func(*Client)
+
+// WithoutValidation disables the validation that Client performs before sending each request.
+func WithoutValidation() ClientOption {
+	return func(c *Client) {
+		c.skipValidation = true
+	}
+}
+
+// WithoutContentCheck disables the check that documents match the format their extension claims,
+// e.g. that a .docx file is an Office Open XML document, which is done while sending each request.
+func WithoutContentCheck() ClientOption {
+	return func(c *Client) {
+		c.skipContentCheck = true
+	}
 }
 
 // NewClient creates a new gotenberg.Client. If http.Client is passed as nil, then http.DefaultClient is used.
-func NewClient(hostname string, httpClient *http.Client) (*Client, error) {
+func NewClient(hostname string, httpClient *http.Client, opts ...ClientOption) (*Client, error) {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
@@ -41,28 +81,96 @@ func NewClient(hostname string, httpClient *http.Client) (*Client, error) {
 		return nil, errEmptyHostname
 	}
 
-	return &Client{
+	c := &Client{
 		hostname:   hostname,
 		httpClient: httpClient,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
 }
 
-// Send sends a request to the Gotenberg API and returns the response.
+// Send sends a request to the Gotenberg API and returns the response. The request is validated
+// beforehand unless the client was created with WithoutValidation.
 func (c *Client) Send(ctx context.Context, req multipartRequester) (*http.Response, error) {
 	return c.send(ctx, req)
 }
 
 func (c *Client) send(ctx context.Context, r multipartRequester) (*http.Response, error) {
-	req, err := c.createRequest(ctx, r, r.endpoint())
+	return c.do(ctx, r, r.endpoint())
+}
+
+func (c *Client) do(ctx context.Context, mr multipartRequester, endpoint string) (*http.Response, error) {
+	// An invalid request is rejected before it can get a cached or shared result.
+	if !c.skipValidation {
+		if err := mr.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.cache == nil && c.flights == nil {
+		return c.roundTrip(ctx, mr, endpoint, "")
+	}
+
+	key, ok, err := cacheKey(mr, endpoint, !c.skipContentCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return c.roundTrip(ctx, mr, endpoint, "")
+	}
+
+	if c.cache != nil {
+		if entry, hit := c.cache.Get(key); hit {
+			// Nothing was uploaded for this result.
+			mr.recordUpload(UploadStats{})
+
+			return cachedResponse(entry), nil
+		}
+	}
+
+	if c.flights != nil {
+		sent := false
+		resp, err := c.flights.do(ctx, key, func(ctx context.Context) (*http.Response, error) {
+			sent = true
+
+			return c.roundTrip(ctx, mr, endpoint, key)
+		})
+		if err == nil && !sent {
+			// The request waited for the call of another one, and uploaded nothing itself.
+			mr.recordUpload(UploadStats{})
+		}
+
+		return resp, err
+	}
+
+	return c.roundTrip(ctx, mr, endpoint, key)
+}
+
+// roundTrip sends a request. If a cache key is given, a successful reply is cached once its body is read.
+func (c *Client) roundTrip(ctx context.Context, mr multipartRequester, endpoint, key string) (*http.Response, error) {
+	req, fw, err := c.createRequest(ctx, mr, endpoint)
 	if err != nil {
 		return nil, err
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, errSendRequestFailed
+		return nil, fmt.Errorf("%w: %w", errSendRequestFailed, err)
+	}
+
+	mr.recordUpload(fw.counter.stats())
+
+	if c.cache != nil && key != "" && resp.StatusCode == http.StatusOK {
+		resp.Body = newCachingBody(resp, c.cache, key)
 	}
 
+	fw.counter.progress.download(resp)
+
 	return resp, nil
 }
 
@@ -117,23 +225,34 @@ func writeNewFile(fpath string, in io.Reader) error {
 	return nil
 }
 
-func (c *Client) createRequest(ctx context.Context, mr multipartRequester, endpoint string) (*http.Request, error) {
-	body, contentType, err := multipartForm(mr)
-	if err != nil {
-		return nil, err
+func (c *Client) createRequest(
+	ctx context.Context,
+	mr multipartRequester,
+	endpoint string,
+) (*http.Request, *formWriter, error) {
+	// The webhook URLs are signed in a copy, so the request can be sent again, or concurrently.
+	headers := maps.Clone(mr.customHeaders())
+	if c.webhookSigner != nil && hasWebhook(mr) {
+		if err := c.webhookSigner.signHeaders(headers, time.Now()); err != nil {
+			return nil, nil, err
+		}
 	}
 
+	body, fw := c.multipartForm(mr)
+
 	url := fmt.Sprintf("%s%s", c.hostname, endpoint)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+		_ = body.Close()
+
+		return nil, nil, fmt.Errorf("creating request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", contentType)
-	for key, value := range mr.customHeaders() {
+	req.Header.Set("Content-Type", fw.writer.FormDataContentType())
+	for key, value := range headers {
 		req.Header.Set(string(key), value)
 	}
 
-	return req, nil
+	return req, fw, nil
 }
@@ -0,0 +1,690 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/starwalkn/gotenberg-go-client/v8"
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+var (
+	errNoIndex    = errors.New("no index.html among the files")
+	errNoURL      = errors.New("expected exactly one URL")
+	errMetadata   = errors.New("-metadata is required")
+	errSplitMode  = errors.New("-mode must be intervals or pages")
+	errSpecOutput = errors.New("-o must be a directory when running several specs")
+)
+
+// chromiumFlags map to the options of the HTML, URL and markdown requests.
+type chromiumFlags struct {
+	paper           string
+	margins         string
+	landscape       bool
+	scale           float64
+	pageRanges      string
+	waitDelay       time.Duration
+	waitFor         string
+	printBackground bool
+	singlePage      bool
+	pdfa            string
+	pdfua           bool
+	media           string
+	header          string
+	footer          string
+	userAgent       string
+
+	format  string
+	width   float64
+	height  float64
+	quality int
+	clip    bool
+}
+
+func (f *chromiumFlags) register(fs *flag.FlagSet, screenshot bool) {
+	fs.DurationVar(&f.waitDelay, "wait-delay", 0, "duration to wait before converting")
+	fs.StringVar(&f.waitFor, "wait-for", "", "JavaScript expression to wait for before converting")
+	fs.StringVar(&f.media, "media", "", "media type to emulate, print or screen")
+	fs.StringVar(&f.userAgent, "user-agent", "", "user agent of Chromium")
+
+	if screenshot {
+		fs.StringVar(&f.format, "format", "", "image format, png, jpeg or webp")
+		fs.Float64Var(&f.width, "width", 0, "device screen width in pixels")
+		fs.Float64Var(&f.height, "height", 0, "device screen height in pixels")
+		fs.IntVar(&f.quality, "quality", 0, "JPEG compression quality, from 0 to 100")
+		fs.BoolVar(&f.clip, "clip", false, "clip the screenshot to the device dimensions")
+
+		return
+	}
+
+	fs.StringVar(&f.paper, "paper", "", "paper size, e.g. A4, Letter or 210mmx297mm")
+	fs.StringVar(&f.margins, "margins", "", "margins in the CSS shorthand, e.g. 1cm or \"1cm 2cm 1cm 2cm\"")
+	fs.BoolVar(&f.landscape, "landscape", false, "landscape orientation")
+	fs.Float64Var(&f.scale, "scale", 0, "scale of the page rendering")
+	fs.StringVar(&f.pageRanges, "page-ranges", "", "page ranges to print, e.g. 1-5,8")
+	fs.BoolVar(&f.printBackground, "print-background", false, "print the background graphics")
+	fs.BoolVar(&f.singlePage, "single-page", false, "print the entire content in one single page")
+	fs.StringVar(&f.pdfa, "pdfa", "", "PDF/A format, e.g. PDF/A-2b")
+	fs.BoolVar(&f.pdfua, "pdfua", false, "PDF/UA for accessibility")
+	fs.StringVar(&f.header, "header", "", "header HTML file")
+	fs.StringVar(&f.footer, "footer", "", "footer HTML file")
+}
+
+// chromiumOptions is the part of the HTML, URL and markdown requests configured by chromiumFlags.
+type chromiumOptions interface {
+	request
+	PaperSize(size gotenberg.PaperDimensions)
+	Margins(margins gotenberg.PageMargins)
+	Landscape()
+	Scale(factor float64)
+	NativePageRanges(ranges string)
+	WaitDelay(delay time.Duration)
+	WaitForExpression(expression string)
+	PrintBackground()
+	SinglePage()
+	PdfA(pdfa gotenberg.PdfAFormat)
+	PdfUA()
+	EmulatePrintMediaType()
+	EmulateScreenMediaType()
+	Header(header document.Document)
+	Footer(footer document.Document)
+	UserAgent(ua string)
+	Format(format gotenberg.ImageFormat)
+	ScreenshotWidth(width float64)
+	ScreenshotHeight(height float64)
+	ScreenshotQuality(quality int)
+	ScreenshotClip()
+}
+
+func (f *chromiumFlags) apply(req chromiumOptions) error {
+	if f.paper != "" {
+		size, err := gotenberg.ParsePaperSize(f.paper)
+		if err != nil {
+			return fmt.Errorf("-paper: %w", err)
+		}
+
+		req.PaperSize(size)
+	}
+
+	if f.margins != "" {
+		margins, err := gotenberg.ParseMargins(f.margins)
+		if err != nil {
+			return fmt.Errorf("-margins: %w", err)
+		}
+
+		req.Margins(margins)
+	}
+
+	switch f.media {
+	case "":
+	case "print":
+		req.EmulatePrintMediaType()
+	case "screen":
+		req.EmulateScreenMediaType()
+	default:
+		return fmt.Errorf("-media must be print or screen, got %q", f.media)
+	}
+
+	for _, h := range []struct {
+		fpath string
+		set   func(document.Document)
+	}{{f.header, req.Header}, {f.footer, req.Footer}} {
+		if h.fpath == "" {
+			continue
+		}
+
+		doc, err := fileDocument(h.fpath)
+		if err != nil {
+			return err
+		}
+
+		h.set(doc)
+	}
+
+	if f.landscape {
+		req.Landscape()
+	}
+
+	if f.scale != 0 {
+		req.Scale(f.scale)
+	}
+
+	if f.pageRanges != "" {
+		req.NativePageRanges(f.pageRanges)
+	}
+
+	if f.waitDelay != 0 {
+		req.WaitDelay(f.waitDelay)
+	}
+
+	if f.waitFor != "" {
+		req.WaitForExpression(f.waitFor)
+	}
+
+	if f.printBackground {
+		req.PrintBackground()
+	}
+
+	if f.singlePage {
+		req.SinglePage()
+	}
+
+	if f.pdfa != "" {
+		req.PdfA(gotenberg.PdfAFormat(f.pdfa))
+	}
+
+	if f.pdfua {
+		req.PdfUA()
+	}
+
+	if f.userAgent != "" {
+		req.UserAgent(f.userAgent)
+	}
+
+	if f.format != "" {
+		req.Format(gotenberg.ImageFormat(f.format))
+	}
+
+	if f.width != 0 {
+		req.ScreenshotWidth(f.width)
+	}
+
+	if f.height != 0 {
+		req.ScreenshotHeight(f.height)
+	}
+
+	if f.quality != 0 {
+		req.ScreenshotQuality(f.quality)
+	}
+
+	if f.clip {
+		req.ScreenshotClip()
+	}
+
+	return nil
+}
+
+func runChromium(ctx context.Context, kind string, screenshot bool, args []string, stdout, stderr io.Writer) error {
+	name := kind
+	if screenshot {
+		name = "screenshot " + kind
+	}
+
+	fs := newFlagSet(name, stderr)
+
+	var (
+		cf commonFlags
+		f  chromiumFlags
+	)
+
+	cf.register(fs)
+	f.register(fs, screenshot)
+
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+
+	var req chromiumOptions
+
+	switch kind {
+	case "url":
+		if len(args) != 1 {
+			return errNoURL
+		}
+
+		req = gotenberg.NewURLRequest(args[0])
+	case "html":
+		index, others, err := splitIndex(args)
+		if err != nil {
+			return err
+		}
+
+		html := gotenberg.NewHTMLRequest(index)
+		html.Assets(others...)
+		req = html
+	default:
+		index, others, err := splitIndex(args)
+		if err != nil {
+			return err
+		}
+
+		var markdowns, assets []document.Document
+
+		for _, doc := range others {
+			if strings.EqualFold(filepath.Ext(doc.Filename()), ".md") {
+				markdowns = append(markdowns, doc)
+			} else {
+				assets = append(assets, doc)
+			}
+		}
+
+		md := gotenberg.NewMarkdownRequest(index, markdowns...)
+		md.Assets(assets...)
+		req = md
+	}
+
+	if err = cf.apply(req); err != nil {
+		return err
+	}
+
+	if err = f.apply(req); err != nil {
+		return err
+	}
+
+	return send(ctx, &cf, req, screenshot, stdout)
+}
+
+// splitIndex returns the index.html among the files, or the first file if there is none, and the other files.
+func splitIndex(args []string) (document.Document, []document.Document, error) {
+	docs, err := documents(args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i, doc := range docs {
+		if doc.Filename() == "index.html" {
+			return doc, append(docs[:i:i], docs[i+1:]...), nil
+		}
+	}
+
+	if filepath.Ext(docs[0].Filename()) != ".html" {
+		return nil, nil, errNoIndex
+	}
+
+	return docs[0], docs[1:], nil
+}
+
+func runOffice(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("office", stderr)
+
+	var (
+		cf         commonFlags
+		merge      bool
+		landscape  bool
+		pageRanges string
+		pdfa       string
+		pdfua      bool
+		quality    int
+		password   string
+	)
+
+	cf.register(fs)
+	fs.BoolVar(&merge, "merge", false, "merge the resulting PDFs")
+	fs.BoolVar(&landscape, "landscape", false, "landscape orientation")
+	fs.StringVar(&pageRanges, "page-ranges", "", "page ranges to convert, e.g. 1-5,8")
+	fs.StringVar(&pdfa, "pdfa", "", "PDF/A format, e.g. PDF/A-2b")
+	fs.BoolVar(&pdfua, "pdfua", false, "PDF/UA for accessibility")
+	fs.IntVar(&quality, "quality", 0, "JPG export quality, from 1 to 100")
+	fs.StringVar(&password, "password", "", "password to open the source files")
+
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+
+	docs, err := documents(args)
+	if err != nil {
+		return err
+	}
+
+	req := gotenberg.NewLibreOfficeRequest(docs...)
+
+	if err = cf.apply(req); err != nil {
+		return err
+	}
+
+	if merge {
+		req.Merge()
+	}
+
+	if landscape {
+		req.Landscape()
+	}
+
+	if pageRanges != "" {
+		req.NativePageRanges(pageRanges)
+	}
+
+	if pdfa != "" {
+		req.PdfA(gotenberg.PdfAFormat(pdfa))
+	}
+
+	if pdfua {
+		req.PdfUA()
+	}
+
+	if quality != 0 {
+		req.Quality(quality)
+	}
+
+	if password != "" {
+		req.Password(password)
+	}
+
+	return send(ctx, &cf, req, false, stdout)
+}
+
+func runMerge(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("merge", stderr)
+
+	var (
+		cf    commonFlags
+		pdfa  string
+		pdfua bool
+	)
+
+	cf.register(fs)
+	fs.StringVar(&pdfa, "pdfa", "", "PDF/A format, e.g. PDF/A-2b")
+	fs.BoolVar(&pdfua, "pdfua", false, "PDF/UA for accessibility")
+
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+
+	docs, err := documents(args)
+	if err != nil {
+		return err
+	}
+
+	req := gotenberg.NewMergeRequest(docs...)
+
+	if err = cf.apply(req); err != nil {
+		return err
+	}
+
+	if pdfa != "" {
+		req.PdfA(gotenberg.PdfAFormat(pdfa))
+	}
+
+	if pdfua {
+		req.PdfUA()
+	}
+
+	return send(ctx, &cf, req, false, stdout)
+}
+
+func runSplit(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("split", stderr)
+
+	var (
+		cf    commonFlags
+		mode  string
+		span  string
+		unify bool
+	)
+
+	cf.register(fs)
+	fs.StringVar(&mode, "mode", "intervals", "split mode, intervals or pages")
+	fs.StringVar(&span, "span", "1", "interval size in intervals mode, page ranges in pages mode")
+	fs.BoolVar(&unify, "unify", false, "put the extracted pages in a single PDF, pages mode only")
+
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+
+	docs, err := documents(args)
+	if err != nil {
+		return err
+	}
+
+	switch mode {
+	case "intervals":
+		var n int
+		if _, err = fmt.Sscan(span, &n); err != nil {
+			return fmt.Errorf("-span must be a number of pages in intervals mode: %w", err)
+		}
+
+		req := gotenberg.NewSplitIntervalsRequest(docs...)
+		req.SplitSpan(n)
+
+		if err = cf.apply(req); err != nil {
+			return err
+		}
+
+		return send(ctx, &cf, req, false, stdout)
+	case "pages":
+		req := gotenberg.NewSplitPagesRequest(docs...)
+		req.SplitSpan(span)
+		req.SplitUnify(unify)
+
+		if err = cf.apply(req); err != nil {
+			return err
+		}
+
+		return send(ctx, &cf, req, false, stdout)
+	default:
+		return errSplitMode
+	}
+}
+
+func runMetadata(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 || (args[0] != "read" && args[0] != "write") {
+		fmt.Fprintln(stderr, "Usage: gotenberg metadata read|write [flags] [files...]")
+
+		return errUsage
+	}
+
+	write := args[0] == "write"
+	fs := newFlagSet("metadata "+args[0], stderr)
+
+	var (
+		cf       commonFlags
+		metadata string
+	)
+
+	cf.register(fs)
+
+	if write {
+		fs.StringVar(&metadata, "metadata", "", "metadata as JSON, or @file to read it from a file")
+	}
+
+	args, err := parse(fs, args[1:])
+	if err != nil {
+		return err
+	}
+
+	docs, err := documents(args)
+	if err != nil {
+		return err
+	}
+
+	if !write {
+		req := gotenberg.NewReadMetadataRequest(docs...)
+
+		if err = cf.apply(req); err != nil {
+			return err
+		}
+
+		return send(ctx, &cf, req, false, stdout)
+	}
+
+	md, err := readMetadata(metadata)
+	if err != nil {
+		return err
+	}
+
+	req := gotenberg.NewWriteMetadataRequest(docs...)
+	req.Metadata(md)
+
+	if err = cf.apply(req); err != nil {
+		return err
+	}
+
+	return send(ctx, &cf, req, false, stdout)
+}
+
+func readMetadata(metadata string) ([]byte, error) {
+	if metadata == "" {
+		return nil, errMetadata
+	}
+
+	md := []byte(metadata)
+
+	if fpath, ok := strings.CutPrefix(metadata, "@"); ok {
+		var err error
+		if md, err = os.ReadFile(fpath); err != nil {
+			return nil, fmt.Errorf("reading metadata: %w", err)
+		}
+	}
+
+	if !json.Valid(md) {
+		return nil, errors.New("-metadata is not valid JSON")
+	}
+
+	return md, nil
+}
+
+func runHealth(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("health", stderr)
+
+	var cf commonFlags
+
+	cf.register(fs)
+
+	if _, err := parse(fs, args); err != nil {
+		return err
+	}
+
+	c, err := cf.client()
+	if err != nil {
+		return err
+	}
+
+	status, err := c.Health(ctx)
+	if status != nil {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+
+		if encErr := enc.Encode(status); encErr != nil {
+			return encErr
+		}
+	}
+
+	return err
+}
+
+func runSpec(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("spec", stderr)
+
+	var cf commonFlags
+
+	cf.register(fs)
+
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+
+	files, err := expand(args)
+	if err != nil {
+		return err
+	}
+
+	if len(files) == 0 {
+		return errNoFiles
+	}
+
+	if len(files) == 1 {
+		return runSpecFile(ctx, &cf, files[0], cf.output, stdout)
+	}
+
+	// With several specs, each result is written to the output directory, named after the output
+	// filename of the spec or after the spec file.
+	if info, err := os.Stat(cf.output); err != nil || !info.IsDir() {
+		return errSpecOutput
+	}
+
+	for _, fpath := range files {
+		spec, err := readSpecFile(fpath)
+		if err != nil {
+			return err
+		}
+
+		name := spec.OutputFilename
+		if name == "" {
+			name = strings.TrimSuffix(filepath.Base(fpath), filepath.Ext(fpath))
+		}
+
+		if err = runSpecFile(ctx, &cf, fpath, filepath.Join(cf.output, name+specExtension(spec)), stdout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readSpecFile(fpath string) (*gotenberg.RequestSpec, error) {
+	f, err := os.Open(fpath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	spec, err := gotenberg.ReadSpec(f)
+	if err != nil {
+		return nil, fmt.Errorf("spec %s: %w", fpath, err)
+	}
+
+	return spec, nil
+}
+
+// specExtension guesses the extension of the result of a spec. Requests which may produce several files
+// reply with a zip archive.
+func specExtension(spec *gotenberg.RequestSpec) string {
+	switch {
+	case spec.Screenshot:
+		if format, ok := spec.Options["format"].(string); ok {
+			return "." + format
+		}
+
+		return ".png"
+	case spec.Type == gotenberg.RequestReadMetadata:
+		return ".json"
+	case spec.Type == gotenberg.RequestSplitIntervals || spec.Type == gotenberg.RequestSplitPages:
+		return ".zip"
+	case spec.Type == gotenberg.RequestLibreOffice && len(spec.Documents) > 1 && spec.Options["merge"] != true:
+		return ".zip"
+	default:
+		return ".pdf"
+	}
+}
+
+func runSpecFile(ctx context.Context, cf *commonFlags, fpath, output string, stdout io.Writer) error {
+	req, spec, err := gotenberg.LoadSpecFile(ctx, fpath)
+	if err != nil {
+		return err
+	}
+
+	specFlags := *cf
+	specFlags.output = output
+
+	// The spec wins over the command-line flags.
+	if spec.OutputFilename != "" {
+		specFlags.outputFilename = ""
+	}
+
+	if spec.Trace != "" {
+		specFlags.trace = ""
+	}
+
+	if r, ok := req.(request); ok {
+		if err = specFlags.apply(r); err != nil {
+			return err
+		}
+	}
+
+	return send(ctx, &specFlags, req, spec.Screenshot, stdout)
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/starwalkn/gotenberg-go-client/v8"
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+const defaultServer = "http://localhost:3000"
+
+var (
+	errNoFiles      = errors.New("no input files")
+	errNoMatch      = errors.New("no files match")
+	errRequestError = errors.New("gotenberg replied")
+)
+
+// commonFlags are the flags shared by every command which sends a request.
+type commonFlags struct {
+	server         string
+	output         string
+	outputFilename string
+	trace          string
+	basicAuth      string
+	timeout        time.Duration
+}
+
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	return fs
+}
+
+func (cf *commonFlags) register(fs *flag.FlagSet) {
+	server := os.Getenv("GOTENBERG_URL")
+	if server == "" {
+		server = defaultServer
+	}
+
+	fs.StringVar(&cf.server, "server", server, "Gotenberg URL, or $GOTENBERG_URL")
+	fs.StringVar(&cf.output, "o", "-", "output file, - for stdout")
+	fs.StringVar(&cf.outputFilename, "output-filename", "", "output filename set by Gotenberg")
+	fs.StringVar(&cf.trace, "trace", "", "trace identifying the request in Gotenberg's logs")
+	fs.StringVar(&cf.basicAuth, "basic-auth", "", "basic authentication credentials, as user:password")
+	fs.DurationVar(&cf.timeout, "timeout", 5*time.Minute, "request timeout")
+}
+
+func (cf *commonFlags) client() (*gotenberg.Client, error) {
+	return gotenberg.NewClient(strings.TrimSuffix(cf.server, "/"), &http.Client{Timeout: cf.timeout})
+}
+
+// request is the part of the requests the CLI configures for every command.
+type request interface {
+	gotenberg.Requester
+
+	OutputFilename(filename string)
+	Trace(trace string)
+	UseBasicAuth(username, password string)
+}
+
+func (cf *commonFlags) apply(req request) error {
+	if cf.outputFilename != "" {
+		req.OutputFilename(cf.outputFilename)
+	}
+
+	if cf.trace != "" {
+		req.Trace(cf.trace)
+	}
+
+	if cf.basicAuth != "" {
+		user, password, ok := strings.Cut(cf.basicAuth, ":")
+		if !ok {
+			return errors.New("-basic-auth must be user:password")
+		}
+
+		req.UseBasicAuth(user, password)
+	}
+
+	return nil
+}
+
+// parse parses the flags of a command and returns its arguments.
+func parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	if err := fs.Parse(args); err != nil {
+		// The flag package already printed the error and the usage.
+		return nil, errUsage
+	}
+
+	return fs.Args(), nil
+}
+
+// expand expands the glob patterns among the arguments.
+func expand(args []string) ([]string, error) {
+	files := make([]string, 0, len(args))
+
+	for _, arg := range args {
+		if !strings.ContainsAny(arg, "*?[") {
+			files = append(files, arg)
+
+			continue
+		}
+
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, fmt.Errorf("glob %s: %w", arg, err)
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("%w %s", errNoMatch, arg)
+		}
+
+		files = append(files, matches...)
+	}
+
+	return files, nil
+}
+
+// documents creates a document from each file given as arguments, or fails if there is none.
+func documents(args []string) ([]document.Document, error) {
+	files, err := expand(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, errNoFiles
+	}
+
+	docs := make([]document.Document, 0, len(files))
+
+	for _, fpath := range files {
+		doc, err := fileDocument(fpath)
+		if err != nil {
+			return nil, err
+		}
+
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+func fileDocument(fpath string) (document.Document, error) {
+	return document.FromPath(filepath.Base(fpath), fpath)
+}
+
+// send sends the request, or takes a screenshot, and writes the result to the output.
+func send(ctx context.Context, cf *commonFlags, req gotenberg.Requester, screenshot bool, stdout io.Writer) error {
+	c, err := cf.client()
+	if err != nil {
+		return err
+	}
+
+	var resp *http.Response
+
+	if screenshot {
+		scr, ok := req.(gotenberg.ScreenshotRequester)
+		if !ok {
+			return fmt.Errorf("%T cannot be sent as a screenshot", req)
+		}
+
+		resp, err = c.Screenshot(ctx, scr)
+	} else {
+		resp, err = c.Send(ctx, req)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return writeResponse(resp, cf.output, stdout)
+}
+
+func writeResponse(resp *http.Response, output string, stdout io.Writer) error {
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+		return fmt.Errorf("%w %d: %s", errRequestError, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if output == "-" {
+		_, err := io.Copy(stdout, resp.Body)
+
+		return err
+	}
+
+	out, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", output, err)
+	}
+
+	if _, err = io.Copy(out, resp.Body); err != nil {
+		_ = out.Close()
+
+		return fmt.Errorf("writing %s: %w", output, err)
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,94 @@
+// Command gotenberg converts documents with a Gotenberg server.
+//
+// Usage:
+//
+//	gotenberg <command> [flags] [files...]
+//
+// The commands are html, url, markdown, office, merge, split, metadata read|write, screenshot html|url|markdown,
+// health and spec. Run "gotenberg <command> -h" for the flags of a command. The server is taken from the
+// -server flag, or from the GOTENBERG_URL environment variable, and defaults to http://localhost:3000.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+var errUsage = errors.New("usage")
+
+const usage = `Usage: gotenberg <command> [flags] [files...]
+
+Commands:
+  html        convert an index.html and its assets to PDF
+  url         convert a web page to PDF
+  markdown    convert an index.html, markdown files and assets to PDF
+  office      convert office documents to PDF
+  merge       merge PDFs
+  split       split PDFs
+  metadata    read or write the metadata of PDFs (metadata read|write)
+  screenshot  take a screenshot of HTML, a web page or markdown (screenshot html|url|markdown)
+  health      check the health of Gotenberg
+  spec        run JSON or YAML request specs
+
+Files can be given as glob patterns. Run "gotenberg <command> -h" for the flags of a command.
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintln(os.Stderr, "gotenberg:", err)
+		}
+
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+
+		return errUsage
+	}
+
+	cmd, args := args[0], args[1:]
+
+	switch cmd {
+	case "html", "url", "markdown":
+		return runChromium(ctx, cmd, false, args, stdout, stderr)
+	case "office":
+		return runOffice(ctx, args, stdout, stderr)
+	case "merge":
+		return runMerge(ctx, args, stdout, stderr)
+	case "split":
+		return runSplit(ctx, args, stdout, stderr)
+	case "metadata":
+		return runMetadata(ctx, args, stdout, stderr)
+	case "screenshot":
+		if len(args) == 0 || (args[0] != "html" && args[0] != "url" && args[0] != "markdown") {
+			fmt.Fprintln(stderr, "Usage: gotenberg screenshot html|url|markdown [flags] [files...]")
+
+			return errUsage
+		}
+
+		return runChromium(ctx, args[0], true, args[1:], stdout, stderr)
+	case "health":
+		return runHealth(ctx, args, stdout, stderr)
+	case "spec":
+		return runSpec(ctx, args, stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+
+		return nil
+	default:
+		fmt.Fprintf(stderr, "gotenberg: unknown command %q\n\n%s", cmd, usage)
+
+		return errUsage
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/gotenbergtest"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+func runCLI(t *testing.T, srv *gotenbergtest.Server, args ...string) ([]byte, error) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+
+	args = append([]string{args[0], "-server", srv.URL}, args[1:]...)
+	err := run(context.Background(), args, &stdout, &stderr)
+
+	return stdout.Bytes(), err
+}
+
+func TestHTML(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	dir := writeFiles(t, map[string]string{
+		"index.html": "<html>Hello</html>",
+		"style.css":  "body {}",
+	})
+
+	out, err := runCLI(t, srv, "html", "-paper", "A4", "-landscape", "-wait-delay", "1s", "-pdfa", "PDF/A-2b",
+		filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-")))
+
+	req, ok := srv.LastRequest()
+	require.True(t, ok)
+	assert.Equal(t, gotenbergtest.RouteHTMLConvert, req.Path)
+	assert.Equal(t, "true", req.Fields["landscape"])
+	assert.Equal(t, "1s", req.Fields["waitDelay"])
+	assert.Equal(t, "PDF/A-2b", req.Fields["pdfa"])
+	assert.Contains(t, req.Files, "index.html")
+	assert.Contains(t, req.Files, "style.css")
+}
+
+func TestMargins(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	dir := writeFiles(t, map[string]string{"index.html": "<html>Hello</html>"})
+
+	_, err := runCLI(t, srv, "html", "-margins", "1cm 2cm 1cm 2cm", filepath.Join(dir, "index.html"))
+	require.NoError(t, err)
+
+	req, ok := srv.LastRequest()
+	require.True(t, ok)
+	assert.Equal(t, "1.000000cm", req.Fields["marginTop"])
+	assert.Equal(t, "2.000000cm", req.Fields["marginRight"])
+	assert.Equal(t, "1.000000cm", req.Fields["marginBottom"])
+	assert.Equal(t, "2.000000cm", req.Fields["marginLeft"])
+}
+
+func TestScreenshotToFile(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	output := filepath.Join(t.TempDir(), "page.png")
+
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), []string{"screenshot", "url", "-server", srv.URL, "-width", "800", "-o", output,
+		"https://example.com"}, &stdout, &stderr)
+	require.NoError(t, err)
+	assert.Zero(t, stdout.Len())
+
+	data, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte("\x89PNG")))
+
+	req, ok := srv.LastRequest()
+	require.True(t, ok)
+	assert.Equal(t, gotenbergtest.RouteURLScreenshot, req.Path)
+	assert.Equal(t, "https://example.com", req.Fields["url"])
+}
+
+func TestGlobWithoutMatch(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	_, err := runCLI(t, srv, "merge", filepath.Join(t.TempDir(), "*.pdf"))
+	require.ErrorIs(t, err, errNoMatch)
+	assert.Empty(t, srv.Requests())
+}
+
+func TestGotenbergError(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	srv.FailNext(1, http.StatusBadRequest)
+
+	dir := writeFiles(t, map[string]string{"a.pdf": "%PDF-1.4", "b.pdf": "%PDF-1.4"})
+
+	_, err := runCLI(t, srv, "merge", filepath.Join(dir, "a.pdf"), filepath.Join(dir, "b.pdf"))
+	require.ErrorIs(t, err, errRequestError)
+}
+
+func TestSpec(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	dir := writeFiles(t, map[string]string{
+		"index.html": "<html>Hello</html>",
+		"spec.yaml":  "type: html\nindex: {path: index.html}\noptions: {landscape: true}\ntrace: from-spec\n",
+	})
+
+	out, err := runCLI(t, srv, "spec", "-trace", "from-flags", filepath.Join(dir, "spec.yaml"))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-")))
+
+	req, ok := srv.LastRequest()
+	require.True(t, ok)
+	assert.Equal(t, gotenbergtest.RouteHTMLConvert, req.Path)
+	assert.Equal(t, "true", req.Fields["landscape"])
+	assert.Equal(t, "from-spec", req.Header.Get("Gotenberg-Trace"))
+}
+
+func TestHealth(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	out, err := runCLI(t, srv, "health")
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `"status": "up"`)
+
+	srv.SetUnavailable(true)
+
+	out, err = runCLI(t, srv, "health")
+	require.Error(t, err)
+	assert.Contains(t, string(out), `"status": "down"`)
+}
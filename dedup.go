@@ -0,0 +1,135 @@
+package gotenberg
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// WithDeduplication makes identical requests sent at the same time share a single call to Gotenberg:
+// the first one is sent, and the others wait for its reply, whose status, headers and body are given to
+// all of them. Requests are identical, or bypass the de-duplication, as with WithCache.
+//
+// The shared call goes on as long as one of the requests waiting for it is not canceled, but no longer
+// than the deadline of the request which started it: the deadlines of the others do not extend it.
+// Progress is only reported to the callback of the request which started the call.
+func WithDeduplication() ClientOption {
+	return func(c *Client) {
+		c.flights = &flightGroup{calls: make(map[string]*flight)}
+	}
+}
+
+// flightGroup runs one call at a time per key.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+// flight is a call shared by the requests waiting for it.
+type flight struct {
+	done   chan struct{}
+	cancel context.CancelFunc
+	// waiters is the number of requests still waiting, guarded by the mutex of the group.
+	waiters int
+
+	status int
+	header http.Header
+	body   []byte
+	err    error
+}
+
+// do runs fn, or waits for the call already running for key, and returns a copy of its reply.
+func (g *flightGroup) do(
+	ctx context.Context,
+	key string,
+	fn func(ctx context.Context) (*http.Response, error),
+) (*http.Response, error) {
+	g.mu.Lock()
+
+	f, ok := g.calls[key]
+	if !ok {
+		// The call must survive the cancellation of the request which started it, as long as others wait,
+		// but keeps its deadline.
+		callCtx := context.WithoutCancel(ctx)
+
+		var cancel context.CancelFunc
+		if deadline, hasDeadline := ctx.Deadline(); hasDeadline {
+			callCtx, cancel = context.WithDeadline(callCtx, deadline)
+		} else {
+			callCtx, cancel = context.WithCancel(callCtx)
+		}
+
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = f
+
+		go g.run(callCtx, key, f, fn)
+	}
+
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			// Nobody waits anymore: stop the call, and let the next request start a new one.
+			f.cancel()
+			g.forget(key, f)
+		}
+		g.mu.Unlock()
+
+		return nil, ctx.Err()
+	}
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return bufferedResponse(f.status, f.header, f.body), nil
+}
+
+func (g *flightGroup) run(
+	ctx context.Context,
+	key string,
+	f *flight,
+	fn func(ctx context.Context) (*http.Response, error),
+) {
+	defer func() {
+		g.mu.Lock()
+		g.forget(key, f)
+		g.mu.Unlock()
+
+		f.cancel()
+		close(f.done)
+	}()
+
+	resp, err := fn(ctx)
+	if err != nil {
+		f.err = err
+
+		return
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		f.err = fmt.Errorf("reading shared reply: %w", err)
+
+		return
+	}
+
+	f.status, f.header, f.body = resp.StatusCode, resp.Header, body
+}
+
+// forget removes the call of key if it is still f. The group must be locked.
+func (g *flightGroup) forget(key string, f *flight) {
+	if g.calls[key] == f {
+		delete(g.calls, key)
+	}
+}
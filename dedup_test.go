@@ -0,0 +1,199 @@
+package gotenberg
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/gotenbergtest"
+)
+
+// sendConcurrently sends the requests built by newReq at the same time and returns the bodies of the replies.
+func sendConcurrently(t *testing.T, c *Client, n int, newReq func() multipartRequester) []string {
+	t.Helper()
+
+	var (
+		wg     sync.WaitGroup
+		bodies = make([]string, n)
+		errs   = make([]error, n)
+	)
+
+	for i := range n {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			resp, err := c.Send(context.Background(), newReq())
+			if err != nil {
+				errs[i] = err
+
+				return
+			}
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			body, err := io.ReadAll(resp.Body)
+			bodies[i], errs[i] = string(body), err
+		}()
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	return bodies
+}
+
+func TestDeduplication(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	srv.SetDelay(100 * time.Millisecond)
+
+	c, err := NewClient(srv.URL, srv.Client(), WithDeduplication())
+	require.NoError(t, err)
+
+	bodies := sendConcurrently(t, c, 5, func() multipartRequester {
+		return newCacheRequest(t, "<html>Report</html>")
+	})
+
+	require.Len(t, srv.Requests(), 1)
+	for _, body := range bodies {
+		assert.Equal(t, bodies[0], body)
+	}
+
+	srv.Reset()
+
+	sendConcurrently(t, c, 3, func() multipartRequester {
+		req := newCacheRequest(t, "<html>Report</html>")
+		req.WaitDelay(time.Millisecond)
+
+		return req
+	})
+
+	assert.Len(t, srv.Requests(), 3, "requests bypassing the cache are not de-duplicated")
+}
+
+func TestDeduplicationSharesFailures(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	srv.SetDelay(100 * time.Millisecond)
+	srv.FailNext(1, http.StatusServiceUnavailable)
+
+	c, err := NewClient(srv.URL, srv.Client(), WithDeduplication())
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			resp, err := c.Send(context.Background(), newCacheRequest(t, "<html>Report</html>"))
+			if assert.NoError(t, err) {
+				_ = resp.Body.Close()
+				assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+			}
+		}()
+	}
+
+	wg.Wait()
+	assert.Len(t, srv.Requests(), 1)
+}
+
+func TestDeduplicationLeaderCanceled(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	srv.SetDelay(200 * time.Millisecond)
+
+	c, err := NewClient(srv.URL, srv.Client(), WithDeduplication())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderDone := make(chan error, 1)
+
+	go func() {
+		_, err := c.Send(ctx, newCacheRequest(t, "<html>Report</html>"))
+		leaderDone <- err
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	waiterDone := make(chan *http.Response, 1)
+
+	go func() {
+		resp, err := c.Send(context.Background(), newCacheRequest(t, "<html>Report</html>"))
+		assert.NoError(t, err)
+		waiterDone <- resp
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	require.ErrorIs(t, <-leaderDone, context.Canceled)
+
+	resp := <-waiterDone
+	require.NotNil(t, resp)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, srv.Requests(), 1)
+}
+
+func TestDeduplicationLeaderDeadline(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	srv.SetDelay(300 * time.Millisecond)
+
+	c, err := NewClient(srv.URL, srv.Client(), WithDeduplication())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	leaderDone := make(chan error, 1)
+
+	go func() {
+		_, err := c.Send(ctx, newCacheRequest(t, "<html>Report</html>"))
+		leaderDone <- err
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	// The waiter has no deadline, but the shared call still stops at the deadline of the leader.
+	_, err = c.Send(context.Background(), newCacheRequest(t, "<html>Report</html>"))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorIs(t, <-leaderDone, context.DeadlineExceeded)
+}
+
+func TestDeduplicationWithCache(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	srv.SetDelay(50 * time.Millisecond)
+
+	c, err := NewClient(srv.URL, srv.Client(), WithDeduplication(), WithCache(NewMemoryCache(1<<20)))
+	require.NoError(t, err)
+
+	newReq := func() multipartRequester {
+		return newCacheRequest(t, "<html>Report</html>")
+	}
+
+	sendConcurrently(t, c, 3, newReq)
+	sendConcurrently(t, c, 3, newReq)
+
+	assert.Len(t, srv.Requests(), 1)
+}
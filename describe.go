@@ -0,0 +1,181 @@
+package gotenberg
+
+import (
+	"encoding/json"
+	"net/url"
+	"sort"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+// Redacted replaces secrets in a Description.
+const Redacted = "REDACTED"
+
+// Description is what a request sends to Gotenberg, with secrets redacted. It can be serialized to JSON,
+// e.g. to attach it to a support ticket.
+type Description struct {
+	// Endpoint is the route the request is sent to by Send and Store.
+	Endpoint string `json:"endpoint"`
+	// ScreenshotEndpoint is the route the request is sent to by Screenshot and StoreScreenshot, if any.
+	ScreenshotEndpoint string            `json:"screenshotEndpoint,omitempty"`
+	Headers            map[string]string `json:"headers,omitempty"`
+	Fields             map[string]string `json:"fields,omitempty"`
+	// Documents are sorted by filename.
+	Documents []DocumentDescription `json:"documents,omitempty"`
+}
+
+// DocumentDescription describes a document of a request.
+type DocumentDescription struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	// ContentType is empty and Size is -1 if the document is missing.
+	// Size is -1 if it is unknown until the document is read, see document.Size.
+	Size int64 `json:"size"`
+}
+
+// Describe returns what the request would send to Gotenberg, without sending it or reading its documents.
+// The Authorization header, passwords, cookie values, the values of extra HTTP headers and webhook
+// signatures are redacted.
+func Describe(req multipartRequester) Description {
+	desc := Description{
+		Endpoint: req.endpoint(),
+		Headers:  make(map[string]string),
+		Fields:   make(map[string]string),
+	}
+
+	if scr, ok := req.(screenshotRequester); ok {
+		desc.ScreenshotEndpoint = scr.screenshotEndpoint()
+	}
+
+	for key, value := range req.customHeaders() {
+		desc.Headers[string(key)] = redactHeader(key, value)
+	}
+
+	for key, value := range req.formFields() {
+		desc.Fields[string(key)] = redactField(key, value)
+	}
+
+	for fname, doc := range req.formDocuments() {
+		if doc == nil {
+			// A missing document is reported by Validate, describe it as unknown.
+			desc.Documents = append(desc.Documents, DocumentDescription{Filename: fname, Size: -1})
+			continue
+		}
+
+		desc.Documents = append(desc.Documents, DocumentDescription{
+			Filename:    fname,
+			ContentType: document.DetectContentType(doc, nil),
+			Size:        document.Size(doc),
+		})
+	}
+
+	sort.Slice(desc.Documents, func(i, j int) bool {
+		return desc.Documents[i].Filename < desc.Documents[j].Filename
+	})
+
+	return desc
+}
+
+func redactHeader(key httpHeader, value string) string {
+	switch key {
+	case headerAuthorization:
+		return Redacted
+	case headerWebhookExtraHeaders:
+		return redactJSON(value, redactMapValues)
+	case headerWebhookURL, headerWebhookErrorURL, headerWebhookEventsURL:
+		return redactSignature(value)
+	default:
+		return value
+	}
+}
+
+func redactField(key formField, value string) string {
+	switch key {
+	case fieldOfficePassword:
+		return Redacted
+	case fieldChromiumExtraHTTPHeaders:
+		return redactJSON(value, redactMapValues)
+	case fieldChromiumCookies:
+		return redactJSON(value, func(v any) {
+			cookies, _ := v.([]any)
+			for _, cookie := range cookies {
+				if c, ok := cookie.(map[string]any); ok {
+					c["value"] = Redacted
+				}
+			}
+		})
+	case fieldDownloadFrom:
+		return redactJSON(value, func(v any) {
+			downloads, _ := v.([]any)
+			for _, download := range downloads {
+				if d, ok := download.(map[string]any); ok {
+					redactMapValues(d["extraHttpHeaders"])
+				}
+			}
+		})
+	default:
+		return value
+	}
+}
+
+// redactJSON applies redact to the decoded value. Invalid JSON is redacted entirely.
+func redactJSON(value string, redact func(v any)) string {
+	var v any
+	if err := json.Unmarshal([]byte(value), &v); err != nil {
+		return Redacted
+	}
+
+	redact(v)
+
+	redacted, err := json.Marshal(v)
+	if err != nil {
+		return Redacted
+	}
+
+	return string(redacted)
+}
+
+func redactMapValues(v any) {
+	if m, ok := v.(map[string]any); ok {
+		for key := range m {
+			m[key] = Redacted
+		}
+	}
+}
+
+func redactSignature(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Redacted
+	}
+
+	query := u.Query()
+	if !query.Has(signatureParam) {
+		return rawURL
+	}
+
+	query.Set(signatureParam, Redacted)
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+// stripSignature removes the query parameters added by webhook signing, which are only valid for one request.
+func stripSignature(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Redacted
+	}
+
+	query := u.Query()
+	if !query.Has(signatureParam) {
+		return rawURL
+	}
+
+	query.Del(signatureParam)
+	query.Del(signatureExpiresParam)
+	query.Del(signatureNonceParam)
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
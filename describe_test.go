@@ -0,0 +1,56 @@
+package gotenberg
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestDescribe(t *testing.T) {
+	index, err := document.FromString("index.html", "<html>Hello</html>")
+	require.NoError(t, err)
+	stream, err := document.FromReader("style.css", strings.NewReader("body {}"))
+	require.NoError(t, err)
+
+	req := NewHTMLRequest(index)
+	req.Assets(stream)
+	req.Landscape()
+	req.UseBasicAuth("foo", "bar")
+	require.NoError(t, req.ExtraHTTPHeaders(map[string]string{"X-Api-Key": "secret"}))
+	require.NoError(t, req.Cookies([]Cookie{{Name: "session", Value: "secret", Domain: "example.com"}}))
+
+	desc := Describe(req)
+
+	assert.Equal(t, "/forms/chromium/convert/html", desc.Endpoint)
+	assert.Equal(t, "/forms/chromium/screenshot/html", desc.ScreenshotEndpoint)
+	assert.Equal(t, Redacted, desc.Headers["Authorization"])
+	assert.Equal(t, "true", desc.Fields["landscape"])
+	assert.JSONEq(t, `{"X-Api-Key":"REDACTED"}`, desc.Fields["extraHttpHeaders"])
+	assert.JSONEq(t, `[{"name":"session","value":"REDACTED","domain":"example.com"}]`, desc.Fields["cookies"])
+	assert.Equal(t, []DocumentDescription{
+		{Filename: "index.html", ContentType: "text/html; charset=utf-8", Size: 18},
+		{Filename: "style.css", ContentType: "text/css; charset=utf-8", Size: -1},
+	}, desc.Documents)
+
+	data, err := json.Marshal(desc)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret")
+}
+
+func TestDescribeSignedWebhook(t *testing.T) {
+	signed, err := newWebhookSigner([]byte("key"), 0).sign("https://example.com/hook", time.Now())
+	require.NoError(t, err)
+
+	req := NewURLRequest("https://example.com")
+	req.UseWebhook(signed, "https://example.com/hook?gotenberg-webhook=error")
+
+	desc := Describe(req)
+	assert.Contains(t, desc.Headers["Gotenberg-Webhook-Url"], "gotenberg-signature=REDACTED")
+	assert.Equal(t, "https://example.com/hook?gotenberg-webhook=error", desc.Headers["Gotenberg-Webhook-Error-Url"])
+}
@@ -0,0 +1,305 @@
+package document
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// SniffLen is the number of leading bytes DetectContentType and CheckContent look at.
+const SniffLen = 4096
+
+// ContentTyper is implemented by documents which know their MIME type.
+type ContentTyper interface {
+	ContentType() string
+}
+
+// nolint: gochecknoglobals
+var contentTypes = map[string]string{
+	".pdf":   "application/pdf",
+	".html":  "text/html; charset=utf-8",
+	".htm":   "text/html; charset=utf-8",
+	".md":    "text/markdown; charset=utf-8",
+	".css":   "text/css; charset=utf-8",
+	".js":    "text/javascript; charset=utf-8",
+	".json":  "application/json",
+	".xml":   "application/xml",
+	".txt":   "text/plain; charset=utf-8",
+	".csv":   "text/csv; charset=utf-8",
+	".rtf":   "application/rtf",
+	".doc":   "application/msword",
+	".xls":   "application/vnd.ms-excel",
+	".ppt":   "application/vnd.ms-powerpoint",
+	".docx":  "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xlsx":  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".pptx":  "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	".odt":   "application/vnd.oasis.opendocument.text",
+	".ods":   "application/vnd.oasis.opendocument.spreadsheet",
+	".odp":   "application/vnd.oasis.opendocument.presentation",
+	".odg":   "application/vnd.oasis.opendocument.graphics",
+	".png":   "image/png",
+	".jpg":   "image/jpeg",
+	".jpeg":  "image/jpeg",
+	".gif":   "image/gif",
+	".webp":  "image/webp",
+	".svg":   "image/svg+xml",
+	".woff":  "font/woff",
+	".woff2": "font/woff2",
+	".ttf":   "font/ttf",
+	".otf":   "font/otf",
+}
+
+// DetectContentType returns the MIME type of a document. It is taken, in order, from the document
+// itself if it implements ContentTyper, from the extension of its filename, or from head, its
+// first bytes, with http.DetectContentType.
+func DetectContentType(doc Document, head []byte) string {
+	if typer, ok := doc.(ContentTyper); ok {
+		if contentType := typer.ContentType(); contentType != "" {
+			return contentType
+		}
+	}
+
+	ext := strings.ToLower(path.Ext(doc.Filename()))
+	if contentType, ok := contentTypes[ext]; ok {
+		return contentType
+	}
+
+	if contentType := mime.TypeByExtension(ext); contentType != "" {
+		return contentType
+	}
+
+	return http.DetectContentType(head)
+}
+
+// ContentMismatchError reports a document whose content does not match the format of its extension.
+type ContentMismatchError struct {
+	Filename string
+	Reason   string
+}
+
+func (e *ContentMismatchError) Error() string {
+	return fmt.Sprintf("%s: content does not match its extension: %s", e.Filename, e.Reason)
+}
+
+// nolint: gochecknoglobals
+var (
+	signaturePDF  = []byte("%PDF-")
+	signatureRTF  = []byte(`{\rtf`)
+	signatureOLE  = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+	signatureZIP  = []byte("PK\x03\x04")
+	signatureDir  = []byte("PK\x01\x02") // zip central directory, after the last entry
+	odfMimePrefix = []byte("application/vnd.oasis.opendocument.")
+)
+
+// CheckContent verifies that head, the first SniffLen bytes of a document (or all of them if it is
+// shorter), matches the format claimed by the extension of its filename. It catches, for instance,
+// a .docx file which is actually a zip archive of something else, before LibreOffice fails on it.
+// Extensions without a well-known signature are not checked.
+func CheckContent(filename string, head []byte) error {
+	mismatch := func(reason string) error {
+		return &ContentMismatchError{Filename: filename, Reason: reason}
+	}
+
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".pdf":
+		// The header may be preceded by garbage, which PDF readers tolerate.
+		if !bytes.Contains(head, signaturePDF) {
+			return mismatch("no PDF header")
+		}
+	case ".rtf":
+		if !bytes.HasPrefix(head, signatureRTF) {
+			return mismatch("no RTF header")
+		}
+	case ".doc", ".xls", ".ppt":
+		// Word and Excel also save RTF, HTML and XML under these extensions, which LibreOffice converts.
+		if !bytes.HasPrefix(head, signatureOLE) && !bytes.HasPrefix(head, signatureRTF) && !isMarkup(head) {
+			return mismatch("not an OLE2 compound document, RTF, HTML or XML")
+		}
+	case ".docx", ".xlsx", ".pptx", ".docm", ".xlsm", ".pptm", ".dotx", ".xltx", ".potx", ".ppsx":
+		return checkOOXML(head, mismatch)
+	case ".odt", ".ods", ".odp", ".odg", ".ott", ".ots", ".otp":
+		return checkODF(head, mismatch)
+	}
+
+	return nil
+}
+
+// checkOOXML looks for the parts of an Office Open XML package among the zip entries whose local header
+// is in head. This is a heuristic: the archive must hold [Content_Types].xml or a word/, xl/ or ppt/ part,
+// but if those start beyond head, a package whose first entries are _rels/, docProps/ or customXml/ parts
+// is accepted without them.
+func checkOOXML(head []byte, mismatch func(reason string) error) error {
+	names, complete := zipEntryNames(head)
+	if len(names) == 0 {
+		return mismatch("not a zip archive")
+	}
+
+	for _, name := range names {
+		if name == "[Content_Types].xml" || hasAnyPrefix(name, "word/", "xl/", "ppt/") {
+			return nil
+		}
+
+		if !hasAnyPrefix(name, "_rels/", "docProps/", "customXml/") {
+			return mismatch(fmt.Sprintf("zip archive entry %q is not part of an Office Open XML document", name))
+		}
+	}
+
+	if complete {
+		return mismatch("zip archive has no Office Open XML content")
+	}
+
+	return nil
+}
+
+func hasAnyPrefix(s string, prefixes ...string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isMarkup reports whether head starts like an HTML or XML document.
+func isMarkup(head []byte) bool {
+	head = bytes.TrimPrefix(head, []byte("\xEF\xBB\xBF"))
+
+	return bytes.HasPrefix(bytes.TrimLeft(head, " \t\r\n"), []byte("<"))
+}
+
+// checkODF checks that the zip archive starts with the uncompressed mimetype entry required by OpenDocument.
+func checkODF(head []byte, mismatch func(reason string) error) error {
+	name, content, ok := firstZipEntry(head)
+	if !ok {
+		return mismatch("not a zip archive")
+	}
+
+	if name != "mimetype" || !bytes.HasPrefix(content, odfMimePrefix) {
+		return mismatch("zip archive is not an OpenDocument file")
+	}
+
+	return nil
+}
+
+// zipEntryNames returns the names of the zip entries whose local header is in head, in order. complete
+// is true if head holds every entry, i.e. the central directory follows the last one.
+func zipEntryNames(head []byte) (names []string, complete bool) {
+	const (
+		headerLen          = 30
+		flagsOffset        = 6
+		compressedOffset   = 18
+		nameLenOffset      = 26
+		extraOffset        = 28
+		flagDataDescriptor = 0x08
+	)
+
+	for offset := 0; offset+headerLen <= len(head); {
+		entry := head[offset:]
+		if bytes.HasPrefix(entry, signatureDir) {
+			return names, true
+		}
+
+		if !bytes.HasPrefix(entry, signatureZIP) {
+			break
+		}
+
+		nameLen := int(binary.LittleEndian.Uint16(entry[nameLenOffset:]))
+		extraLen := int(binary.LittleEndian.Uint16(entry[extraOffset:]))
+
+		if len(entry) < headerLen+nameLen {
+			break
+		}
+
+		names = append(names, string(entry[headerLen:headerLen+nameLen]))
+		start := headerLen + nameLen + extraLen
+
+		// With a data descriptor, the size follows the content, so look for the next header instead.
+		if binary.LittleEndian.Uint16(entry[flagsOffset:])&flagDataDescriptor != 0 {
+			next := nextZipHeader(entry, start)
+			if next < 0 {
+				break
+			}
+
+			offset += next
+
+			continue
+		}
+
+		offset += start + int(binary.LittleEndian.Uint32(entry[compressedOffset:]))
+	}
+
+	return names, false
+}
+
+// nextZipHeader returns the offset of the next local or central directory header in entry after from, or -1.
+func nextZipHeader(entry []byte, from int) int {
+	if from > len(entry) {
+		return -1
+	}
+
+	for i := from; i < len(entry); i++ {
+		if bytes.HasPrefix(entry[i:], signatureZIP) || bytes.HasPrefix(entry[i:], signatureDir) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// firstZipEntry returns the name of the first entry of a zip archive and the start of its raw content.
+func firstZipEntry(head []byte) (name string, content []byte, ok bool) {
+	const (
+		headerLen     = 30
+		nameLenOffset = 26
+		extraOffset   = 28
+	)
+
+	if !bytes.HasPrefix(head, signatureZIP) || len(head) < headerLen {
+		return "", nil, false
+	}
+
+	nameLen := int(binary.LittleEndian.Uint16(head[nameLenOffset:]))
+	extraLen := int(binary.LittleEndian.Uint16(head[extraOffset:]))
+
+	if len(head) < headerLen+nameLen {
+		return "", nil, false
+	}
+
+	name = string(head[headerLen : headerLen+nameLen])
+	if start := headerLen + nameLen + extraLen; start <= len(head) {
+		content = head[start:]
+	}
+
+	return name, content, true
+}
+
+type documentWithContentType struct {
+	contentType string
+
+	Document
+}
+
+// WithContentType returns the document with an explicit MIME type, which takes precedence over detection.
+func WithContentType(doc Document, contentType string) Document {
+	return &documentWithContentType{contentType: contentType, Document: doc}
+}
+
+func (doc *documentWithContentType) ContentType() string {
+	return doc.contentType
+}
+
+func (doc *documentWithContentType) FilenameResolved() bool {
+	return FilenameResolved(doc.Document)
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = ContentTyper(new(documentWithContentType))
+	_ = ContentTyper(new(renamedDocument))
+	_ = FilenameResolver(new(documentWithContentType))
+)
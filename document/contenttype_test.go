@@ -0,0 +1,95 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func zipArchive(t *testing.T, entries ...string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	for _, name := range entries {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store})
+		if err != nil {
+			t.Fatalf("failed to create zip entry: %v", err)
+		}
+
+		if name == "mimetype" {
+			_, _ = w.Write([]byte("application/vnd.oasis.opendocument.text"))
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close zip writer: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		doc  Document
+		head []byte
+		want string
+	}{
+		{doc: &documentFromString{data: "x", document: &document{filename: "report.docx"}}, want: contentTypes[".docx"]},
+		{doc: &documentFromString{data: "x", document: &document{filename: "INDEX.HTML"}}, want: "text/html; charset=utf-8"},
+		{doc: &documentFromString{data: "x", document: &document{filename: "unknown"}}, head: []byte("%PDF-1.4"), want: "application/pdf"},
+		{doc: WithContentType(&documentFromString{data: "x", document: &document{filename: "data"}}, "application/x-custom"), want: "application/x-custom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.doc.Filename(), func(t *testing.T) {
+			if got := DetectContentType(tt.doc, tt.head); got != tt.want {
+				t.Errorf("expected content type %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCheckContent(t *testing.T) {
+	tests := []struct {
+		filename string
+		head     []byte
+		valid    bool
+	}{
+		{filename: "doc.pdf", head: []byte("%PDF-1.7"), valid: true},
+		{filename: "doc.pdf", head: []byte("<html>"), valid: false},
+		{filename: "doc.rtf", head: []byte(`{\rtf1`), valid: true},
+		{filename: "doc.doc", head: []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}, valid: true},
+		{filename: "doc.xls", head: []byte("PK\x03\x04"), valid: false},
+		{filename: "rtf.doc", head: []byte(`{\rtf1\ansi`), valid: true},
+		{filename: "html.xls", head: []byte("\xEF\xBB\xBF\r\n<html xmlns:o=\"urn:schemas-microsoft-com:office:office\">"), valid: true},
+		{filename: "xml.doc", head: []byte(`<?xml version="1.0"?><?mso-application progid="Word.Document"?>`), valid: true},
+		{filename: "text.ppt", head: []byte("plain text"), valid: false},
+		{filename: "doc.docx", head: zipArchive(t, "[Content_Types].xml", "word/document.xml"), valid: true},
+		{filename: "last.docx", head: zipArchive(t, "_rels/.rels", "docProps/app.xml", "word/document.xml", "[Content_Types].xml"), valid: true},
+		{filename: "truncated.docx", head: zipArchive(t, "_rels/.rels", "docProps/app.xml")[:60], valid: true},
+		{filename: "props.docx", head: zipArchive(t, "docProps/app.xml", "_rels/.rels"), valid: false},
+		{filename: "mixed.docx", head: zipArchive(t, "_rels/.rels", "evil.exe"), valid: false},
+		{filename: "doc.DOCX", head: zipArchive(t, "evil.exe"), valid: false},
+		{filename: "doc.docx", head: []byte("plain text"), valid: false},
+		{filename: "doc.odt", head: zipArchive(t, "mimetype", "content.xml"), valid: true},
+		{filename: "doc.odt", head: zipArchive(t, "content.xml"), valid: false},
+		{filename: "doc.txt", head: []byte{0x00, 0x01}, valid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			err := CheckContent(tt.filename, tt.head)
+			if tt.valid && err != nil {
+				t.Fatalf("expected valid content, got %v", err)
+			}
+
+			var mismatch *ContentMismatchError
+			if !tt.valid && !errors.As(err, &mismatch) {
+				t.Fatalf("expected content mismatch error, got %v", err)
+			}
+		})
+	}
+}
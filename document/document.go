@@ -5,11 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"path"
 	"strings"
 )
 
-var errEmptyContent = errors.New("empty content passed")
+var (
+	errEmptyContent      = errors.New("empty content passed")
+	errNoMatch           = errors.New("no files match pattern")
+	errFilenameCollision = errors.New("files have the same flattened filename")
+)
 
 // Document represents a file which will be sent to the Gotenberg API.
 type Document interface {
@@ -118,6 +124,105 @@ func (doc *documentFromReader) Reader() (io.ReadCloser, error) {
 	return io.NopCloser(doc.r), nil
 }
 
+type documentFromFS struct {
+	fsys fs.FS
+	name string
+
+	*document
+}
+
+// FromFS creates a Document from a file of fsys, e.g. an embed.FS. As Gotenberg requires, the filename
+// of the document is flattened to the base name of the file.
+func FromFS(fsys fs.FS, name string) (Document, error) {
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("file %s: %w", name, err)
+	}
+
+	if info.IsDir() {
+		return nil, fmt.Errorf("file %s is a directory", name)
+	}
+
+	return &documentFromFS{
+		fsys:     fsys,
+		name:     name,
+		document: &document{filename: path.Base(name)},
+	}, nil
+}
+
+func (doc *documentFromFS) Reader() (io.ReadCloser, error) {
+	in, err := doc.fsys.Open(doc.name)
+	if err != nil {
+		return nil, fmt.Errorf("opening file %s: %w", doc.name, err)
+	}
+
+	return in, nil
+}
+
+// Glob creates a Document from each file of fsys matching the pattern, with the syntax of fs.Glob.
+// Directories are skipped. It fails if nothing matches, or if two files have the same flattened filename.
+func Glob(fsys fs.FS, pattern string) ([]Document, error) {
+	matches, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("glob %s: %w", pattern, err)
+	}
+
+	docs := make([]Document, 0, len(matches))
+	owners := make(map[string]string, len(matches))
+
+	for _, name := range matches {
+		info, statErr := fs.Stat(fsys, name)
+		if statErr != nil {
+			return nil, fmt.Errorf("file %s: %w", name, statErr)
+		}
+
+		if info.IsDir() {
+			continue
+		}
+
+		flat := path.Base(name)
+		if owner, ok := owners[flat]; ok {
+			return nil, fmt.Errorf("%w: %s and %s are both %s", errFilenameCollision, owner, name, flat)
+		}
+		owners[flat] = name
+
+		docs = append(docs, &documentFromFS{
+			fsys:     fsys,
+			name:     name,
+			document: &document{filename: flat},
+		})
+	}
+
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("%w: %s", errNoMatch, pattern)
+	}
+
+	return docs, nil
+}
+
+type renamedDocument struct {
+	filename string
+
+	Document
+}
+
+// Rename returns the document sent under another filename. Its content type, size and source are kept.
+func Rename(doc Document, fname string) Document {
+	return &renamedDocument{filename: fname, Document: doc}
+}
+
+func (doc *renamedDocument) Filename() string {
+	return doc.filename
+}
+
+func (doc *renamedDocument) ContentType() string {
+	if typer, ok := doc.Document.(ContentTyper); ok {
+		return typer.ContentType()
+	}
+
+	return ""
+}
+
 func fileExists(name string) bool {
 	_, err := os.Stat(name)
 
@@ -130,4 +235,6 @@ var (
 	_ = Document(new(documentFromString))
 	_ = Document(new(documentFromBytes))
 	_ = Document(new(documentFromReader))
+	_ = Document(new(documentFromFS))
+	_ = Document(new(renamedDocument))
 )
@@ -2,10 +2,13 @@ package document
 
 import (
 	"bytes"
+	"errors"
 	"io"
+	"io/fs"
 	"os"
 	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestFromPath(t *testing.T) {
@@ -160,6 +163,27 @@ func TestFromBytes(t *testing.T) {
 	})
 }
 
+func TestRename(t *testing.T) {
+	doc, err := FromBytes("report.pdf", []byte("%PDF-1.4"))
+	if err != nil {
+		t.Fatalf("FromBytes failed: %v", err)
+	}
+
+	renamed := Rename(WithContentType(doc, "application/x-pdf"), "001_report.pdf")
+
+	if renamed.Filename() != "001_report.pdf" {
+		t.Errorf("expected filename 001_report.pdf, got %s", renamed.Filename())
+	}
+
+	if contentType := DetectContentType(renamed, nil); contentType != "application/x-pdf" {
+		t.Errorf("expected content type application/x-pdf, got %s", contentType)
+	}
+
+	if size := Size(renamed); size != 8 {
+		t.Errorf("expected size 8, got %d", size)
+	}
+}
+
 func TestFromReader(t *testing.T) {
 	t.Run("ValidReader", func(t *testing.T) {
 		data := "this is test content"
@@ -203,3 +227,91 @@ func TestFromReader(t *testing.T) {
 		}
 	})
 }
+
+func TestFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"templates/css/style.css": {Data: []byte("body {}")},
+	}
+
+	t.Run("FileExists", func(t *testing.T) {
+		doc, err := FromFS(fsys, "templates/css/style.css")
+		if err != nil {
+			t.Fatalf("FromFS failed for existing file: %v", err)
+		}
+
+		if doc.Filename() != "style.css" {
+			t.Errorf("expected filename %s, got %s", "style.css", doc.Filename())
+		}
+
+		reader, err := doc.Reader()
+		if err != nil {
+			t.Fatalf("Reader failed: %v", err)
+		}
+		defer func(reader io.ReadCloser) {
+			_ = reader.Close()
+		}(reader)
+
+		readData, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("failed to read from reader: %v", err)
+		}
+
+		if string(readData) != "body {}" {
+			t.Errorf("expected data %q, got %q", "body {}", string(readData))
+		}
+	})
+
+	t.Run("FileDoesNotExist", func(t *testing.T) {
+		_, err := FromFS(fsys, "templates/missing.css")
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("expected fs.ErrNotExist for nonexistent file, got %v", err)
+		}
+	})
+
+	t.Run("Directory", func(t *testing.T) {
+		_, err := FromFS(fsys, "templates/css")
+		if err == nil {
+			t.Fatalf("expected error for directory, got nil")
+		}
+	})
+}
+
+func TestGlob(t *testing.T) {
+	fsys := fstest.MapFS{
+		"assets/img/logo.png":  {Data: []byte("png")},
+		"assets/img/bg.png":    {Data: []byte("png")},
+		"assets/img/icons/x":   {Data: []byte("x")},
+		"assets/other/bg.png":  {Data: []byte("png")},
+		"assets/fonts/a.woff2": {Data: []byte("woff2")},
+	}
+
+	t.Run("Matches", func(t *testing.T) {
+		docs, err := Glob(fsys, "assets/img/*")
+		if err != nil {
+			t.Fatalf("Glob failed: %v", err)
+		}
+
+		if len(docs) != 2 {
+			t.Fatalf("expected 2 documents, got %d", len(docs))
+		}
+
+		if docs[0].Filename() != "bg.png" || docs[1].Filename() != "logo.png" {
+			t.Errorf("expected flattened filenames bg.png and logo.png, got %s and %s",
+				docs[0].Filename(), docs[1].Filename())
+		}
+	})
+
+	t.Run("Collision", func(t *testing.T) {
+		_, err := Glob(fsys, "assets/*/bg.png")
+		if !errors.Is(err, errFilenameCollision) {
+			t.Fatalf("expected collision error, got %v", err)
+		}
+	})
+
+	t.Run("NoMatch", func(t *testing.T) {
+		_, err := Glob(fsys, "assets/*.css")
+		if !errors.Is(err, errNoMatch) {
+			t.Fatalf("expected no match error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package document
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// Sizer is implemented by documents which know their size without being read.
+type Sizer interface {
+	Size() (int64, error)
+}
+
+// Size returns the size of a document in bytes, or -1 if it is unknown until the document is read,
+// e.g. for documents created with FromReader or FromURL.
+func Size(doc Document) int64 {
+	sizer, ok := doc.(Sizer)
+	if !ok {
+		return -1
+	}
+
+	size, err := sizer.Size()
+	if err != nil {
+		return -1
+	}
+
+	return size
+}
+
+func (doc *documentFromPath) Size() (int64, error) {
+	info, err := os.Stat(doc.fpath)
+	if err != nil {
+		return 0, fmt.Errorf("file %s: %w", doc.Filename(), err)
+	}
+
+	return info.Size(), nil
+}
+
+func (doc *documentFromString) Size() (int64, error) {
+	return int64(len(doc.data)), nil
+}
+
+func (doc *documentFromBytes) Size() (int64, error) {
+	return int64(len(doc.data)), nil
+}
+
+func (doc *documentFromFS) Size() (int64, error) {
+	info, err := fs.Stat(doc.fsys, doc.name)
+	if err != nil {
+		return 0, fmt.Errorf("file %s: %w", doc.name, err)
+	}
+
+	return info.Size(), nil
+}
+
+func (doc *documentWithContentType) Size() (int64, error) {
+	return Size(doc.Document), nil
+}
+
+func (doc *renamedDocument) Size() (int64, error) {
+	return Size(doc.Document), nil
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = Sizer(new(documentFromPath))
+	_ = Sizer(new(documentFromString))
+	_ = Sizer(new(documentFromBytes))
+	_ = Sizer(new(documentFromFS))
+	_ = Sizer(new(documentWithContentType))
+	_ = Sizer(new(renamedDocument))
+)
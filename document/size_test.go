@@ -0,0 +1,43 @@
+package document
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestSize(t *testing.T) {
+	fpath := filepath.Join(t.TempDir(), "doc.txt")
+	if err := os.WriteFile(fpath, []byte("12345"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	fromPath, _ := FromPath("doc.txt", fpath)
+	fromString, _ := FromString("doc.txt", "1234")
+	fromBytes, _ := FromBytes("doc.txt", []byte("123"))
+	fromFS, _ := FromFS(fstest.MapFS{"doc.txt": {Data: []byte("12")}}, "doc.txt")
+	fromReader, _ := FromReader("doc.txt", strings.NewReader("1"))
+
+	tests := []struct {
+		name string
+		doc  Document
+		want int64
+	}{
+		{"FromPath", fromPath, 5},
+		{"FromString", fromString, 4},
+		{"FromBytes", fromBytes, 3},
+		{"FromFS", fromFS, 2},
+		{"FromReader", fromReader, -1},
+		{"WithContentType", WithContentType(fromString, "text/plain"), 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Size(tt.doc); got != tt.want {
+				t.Errorf("expected size %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
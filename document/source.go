@@ -0,0 +1,57 @@
+package document
+
+// Source is where a document is read from.
+type Source struct {
+	// Path is the file path of a document created with FromPath.
+	Path string
+	// URL is the URL of a document created with FromURL.
+	URL string
+	// Filename is the filename imposed on a document created with FromURL, with URLOptions.Filename or
+	// Rename, if any.
+	Filename string
+}
+
+// Sourcer is implemented by documents which are read from a file path or a URL.
+type Sourcer interface {
+	Source() Source
+}
+
+// SourceOf returns where a document is read from. It returns false for documents held in memory
+// or read from a reader or an fs.FS.
+func SourceOf(doc Document) (Source, bool) {
+	sourcer, ok := doc.(Sourcer)
+	if !ok {
+		return Source{}, false
+	}
+
+	return sourcer.Source(), true
+}
+
+func (doc *documentFromPath) Source() Source {
+	return Source{Path: doc.fpath}
+}
+
+func (doc *documentFromURL) Source() Source {
+	return Source{URL: doc.url, Filename: doc.opts.Filename}
+}
+
+func (doc *documentWithContentType) Source() Source {
+	source, _ := SourceOf(doc.Document)
+
+	return source
+}
+
+func (doc *renamedDocument) Source() Source {
+	source, _ := SourceOf(doc.Document)
+	if source.URL != "" {
+		source.Filename = doc.filename
+	}
+
+	return source
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = Sourcer(new(documentFromPath))
+	_ = Sourcer(new(documentFromURL))
+)
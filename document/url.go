@@ -0,0 +1,187 @@
+package document
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"sync"
+)
+
+var errUnexpectedStatus = errors.New("unexpected status code")
+
+// URLOptions configures how FromURL fetches a document.
+type URLOptions struct {
+	// HTTPClient fetches the document. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+	// Header is sent with the request, e.g. for authentication.
+	Header http.Header
+	// Filename overrides the filename taken from the response or from the URL.
+	Filename string
+}
+
+// FilenameResolver is implemented by documents whose filename may change when they are read.
+type FilenameResolver interface {
+	// FilenameResolved reports whether the filename is final.
+	FilenameResolved() bool
+}
+
+// FilenameResolved reports whether the filename of a document is final. It is not for documents created
+// with FromURL without URLOptions.Filename until they are fetched, so checks of their filename, such as
+// its extension, should wait until then.
+func FilenameResolved(doc Document) bool {
+	resolver, ok := doc.(FilenameResolver)
+	if !ok {
+		return true
+	}
+
+	return resolver.FilenameResolved()
+}
+
+type documentFromURL struct {
+	ctx  context.Context
+	url  string
+	opts URLOptions
+
+	mu          sync.Mutex
+	contentType string
+	fetched     bool
+
+	*document
+}
+
+// FromURL creates a Document which is fetched by the client itself, when the request body is built,
+// and streamed into it. Unlike Gotenberg's downloadFrom, this works for files which Gotenberg cannot
+// reach or which require client-side authentication.
+//
+// Until the document is fetched, its filename is the last element of the URL path. It is then replaced
+// by the filename of the Content-Disposition response header, if any, unless opts.Filename is set.
+// Request validation does not check the filename until then, see FilenameResolved: the client fetches
+// such documents before it sends the request, and validates it again with their final filenames.
+// The opts may be nil.
+func FromURL(ctx context.Context, rawURL string, opts *URLOptions) (Document, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing URL %s: %w", rawURL, err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("URL %s: unsupported scheme %q", rawURL, u.Scheme)
+	}
+
+	doc := &documentFromURL{ctx: ctx, url: rawURL}
+	if opts != nil {
+		doc.opts = *opts
+	}
+
+	fname := doc.opts.Filename
+	if fname == "" {
+		fname = path.Base(u.Path)
+		if fname == "/" || fname == "." {
+			fname = u.Hostname()
+		}
+	}
+
+	doc.document = &document{filename: fname}
+
+	return doc, nil
+}
+
+func (doc *documentFromURL) Filename() string {
+	doc.mu.Lock()
+	defer doc.mu.Unlock()
+
+	return doc.filename
+}
+
+func (doc *documentFromURL) FilenameResolved() bool {
+	doc.mu.Lock()
+	defer doc.mu.Unlock()
+
+	return doc.fetched || doc.opts.Filename != ""
+}
+
+// ContentType returns the Content-Type of the response, once the document has been fetched.
+func (doc *documentFromURL) ContentType() string {
+	doc.mu.Lock()
+	defer doc.mu.Unlock()
+
+	return doc.contentType
+}
+
+func (doc *documentFromURL) Reader() (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(doc.ctx, http.MethodGet, doc.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request for %s: %w", doc.url, err)
+	}
+
+	for key, values := range doc.opts.Header {
+		req.Header[key] = values
+	}
+
+	client := doc.opts.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", doc.url, err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		_ = resp.Body.Close()
+
+		return nil, fmt.Errorf("fetching %s: %w: %d", doc.url, errUnexpectedStatus, resp.StatusCode)
+	}
+
+	doc.mu.Lock()
+	defer doc.mu.Unlock()
+
+	doc.fetched = true
+
+	if doc.opts.Filename == "" {
+		if fname := DispositionFilename(resp.Header.Get("Content-Disposition")); fname != "" {
+			doc.filename = fname
+		}
+	}
+
+	// A generic type tells nothing, so let detection use the filename instead.
+	if contentType := resp.Header.Get("Content-Type"); contentType != "application/octet-stream" {
+		doc.contentType = contentType
+	}
+
+	return resp.Body, nil
+}
+
+// DispositionFilename returns the base name of the filename parameter of a Content-Disposition header,
+// or an empty string if there is none.
+func DispositionFilename(disposition string) string {
+	if disposition == "" {
+		return ""
+	}
+
+	_, params, err := mime.ParseMediaType(disposition)
+	if err != nil {
+		return ""
+	}
+
+	// Only keep the base name, the header must not choose where the file goes.
+	fname := path.Base(params["filename"])
+	if fname == "." || fname == "/" {
+		return ""
+	}
+
+	return fname
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = Document(new(documentFromURL))
+	_ = ContentTyper(new(documentFromURL))
+	_ = FilenameResolver(new(documentFromURL))
+)
@@ -0,0 +1,122 @@
+package document
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFromURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files/report":
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			w.Header().Set("Content-Disposition", `attachment; filename="../report.docx"`)
+			_, _ = w.Write([]byte("docx"))
+		case "/files/data.pdf":
+			_, _ = w.Write([]byte("%PDF-"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	t.Run("ContentDisposition", func(t *testing.T) {
+		doc, err := FromURL(context.Background(), srv.URL+"/files/report", &URLOptions{
+			HTTPClient: srv.Client(),
+			Header:     http.Header{"Authorization": {"Bearer token"}},
+		})
+		if err != nil {
+			t.Fatalf("FromURL failed: %v", err)
+		}
+
+		if doc.Filename() != "report" {
+			t.Errorf("expected filename %s before fetching, got %s", "report", doc.Filename())
+		}
+
+		if FilenameResolved(doc) {
+			t.Errorf("expected filename to be unresolved before fetching")
+		}
+
+		reader, err := doc.Reader()
+		if err != nil {
+			t.Fatalf("Reader failed: %v", err)
+		}
+		defer func(reader io.ReadCloser) {
+			_ = reader.Close()
+		}(reader)
+
+		readData, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("failed to read from reader: %v", err)
+		}
+
+		if string(readData) != "docx" {
+			t.Errorf("expected data %q, got %q", "docx", string(readData))
+		}
+
+		if doc.Filename() != "report.docx" {
+			t.Errorf("expected filename %s after fetching, got %s", "report.docx", doc.Filename())
+		}
+
+		if !FilenameResolved(doc) {
+			t.Errorf("expected filename to be resolved after fetching")
+		}
+	})
+
+	t.Run("URLPath", func(t *testing.T) {
+		doc, err := FromURL(context.Background(), srv.URL+"/files/data.pdf", nil)
+		if err != nil {
+			t.Fatalf("FromURL failed: %v", err)
+		}
+
+		if doc.Filename() != "data.pdf" {
+			t.Errorf("expected filename %s, got %s", "data.pdf", doc.Filename())
+		}
+	})
+
+	t.Run("FilenameOverride", func(t *testing.T) {
+		doc, err := FromURL(context.Background(), srv.URL+"/files/report", &URLOptions{
+			Header:   http.Header{"Authorization": {"Bearer token"}},
+			Filename: "custom.docx",
+		})
+		if err != nil {
+			t.Fatalf("FromURL failed: %v", err)
+		}
+
+		reader, err := doc.Reader()
+		if err != nil {
+			t.Fatalf("Reader failed: %v", err)
+		}
+		_ = reader.Close()
+
+		if doc.Filename() != "custom.docx" {
+			t.Errorf("expected filename %s, got %s", "custom.docx", doc.Filename())
+		}
+	})
+
+	t.Run("UnexpectedStatus", func(t *testing.T) {
+		doc, err := FromURL(context.Background(), srv.URL+"/missing", nil)
+		if err != nil {
+			t.Fatalf("FromURL failed: %v", err)
+		}
+
+		if _, err = doc.Reader(); !errors.Is(err, errUnexpectedStatus) {
+			t.Fatalf("expected unexpected status error, got %v", err)
+		}
+	})
+
+	t.Run("InvalidScheme", func(t *testing.T) {
+		if _, err := FromURL(context.Background(), "ftp://example.com/file.pdf", nil); err == nil {
+			t.Fatalf("expected error for unsupported scheme, got nil")
+		}
+	})
+}
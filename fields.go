@@ -91,8 +91,9 @@ const (
 	fieldMergePdfUA formField = "pdfua"
 )
 
+// Split request properties.
 const (
-	fieldSplitMode  = "splitMode"
-	fieldSplitSpan  = "splitSpan"
-	fieldSplitUnify = "splitUnify"
+	fieldSplitMode  formField = "splitMode"
+	fieldSplitSpan  formField = "splitSpan"
+	fieldSplitUnify formField = "splitUnify"
 )
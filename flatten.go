@@ -0,0 +1,47 @@
+package gotenberg
+
+import (
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+const endpointFlatten = "/forms/pdfengines/flatten"
+
+// flattenRequest merges the annotations and form fields of PDF files into their content. It is only
+// sent by the Flatten step of pipelines.
+type flattenRequest struct {
+	pdfs []document.Document
+
+	*baseRequest
+}
+
+func newFlattenRequest(pdfs ...document.Document) *flattenRequest {
+	return &flattenRequest{pdfs, newBaseRequest()}
+}
+
+func (req *flattenRequest) endpoint() string {
+	return endpointFlatten
+}
+
+func (req *flattenRequest) formDocuments() map[string]document.Document {
+	files := make(map[string]document.Document)
+
+	for _, pdf := range req.pdfs {
+		files[pdf.Filename()] = pdf
+	}
+
+	return files
+}
+
+// Validate checks the request options before it is sent.
+func (req *flattenRequest) Validate() error {
+	v := &validator{}
+	v.documents("files", req.pdfs, req.requiredDocuments(1), ".pdf")
+	v.validateBase(req.baseRequest)
+
+	return v.err()
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = multipartRequester(new(flattenRequest))
+)
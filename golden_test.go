@@ -0,0 +1,92 @@
+package gotenberg
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+	"github.com/starwalkn/gotenberg-go-client/v8/gotenbergtest"
+	"github.com/starwalkn/gotenberg-go-client/v8/test"
+)
+
+// nolint: gochecknoglobals
+var updateGolden = flag.Bool("update", false, "record the golden files against the fake Gotenberg server")
+
+// TestGoldenRequests checks the exact fields, headers and documents each builder sends.
+// Run it with -update to record them again after an intended change.
+func TestGoldenRequests(t *testing.T) {
+	golden := test.GoldenTestFilePath(t, "requests.json")
+
+	var transport http.RoundTripper
+	var recorder *gotenbergtest.Recorder
+	hostname := "http://gotenberg"
+
+	if *updateGolden {
+		srv := gotenbergtest.NewServer()
+		defer srv.Close()
+
+		recorder = gotenbergtest.NewRecorder(srv.Client().Transport)
+		transport, hostname = recorder, srv.URL
+	} else {
+		replayer, err := gotenbergtest.LoadReplayer(golden)
+		require.NoError(t, err)
+
+		transport = replayer
+	}
+
+	c, err := NewClient(hostname, &http.Client{Transport: transport})
+	require.NoError(t, err)
+
+	index, err := document.FromPath("index.html", test.HTMLTestFilePath(t, "index.html"))
+	require.NoError(t, err)
+	pdf, err := document.FromPath("gotenberg.pdf", test.PDFTestFilePath(t, "gotenberg.pdf"))
+	require.NoError(t, err)
+	docx, err := document.FromPath("document.docx", test.LibreOfficeTestFilePath(t, "document.docx"))
+	require.NoError(t, err)
+
+	html := NewHTMLRequest(index)
+	html.Trace("golden-html")
+	html.Landscape()
+	html.PrintBackground()
+	html.Scale(0.9)
+	html.PaperSize(A4)
+	html.Margins(NormalMargins)
+	html.NativePageRangesOf(Range(1, 2))
+	html.EmulatePrintMediaType()
+	html.SkipNetworkIdleEvent(true)
+	html.PdfA(PdfA3b)
+
+	url := NewURLRequest("https://example.com")
+	url.Trace("golden-url")
+	url.UserAgent("golden")
+	url.SinglePage()
+	url.GenerateDocumentOutline()
+
+	office := NewLibreOfficeRequest(docx)
+	office.Trace("golden-office")
+	office.Landscape()
+	office.ExportBookmarks(true)
+	office.Quality(90)
+	office.MaxImageResolution(300)
+
+	merge := NewMergeRequest(pdf)
+	merge.Trace("golden-merge")
+
+	split := NewSplitIntervalsRequest(pdf)
+	split.Trace("golden-split")
+	split.SplitSpan(1)
+
+	for _, req := range []multipartRequester{html, url, office, merge, split} {
+		resp, err := c.Send(context.Background(), req)
+		require.NoError(t, err, "request to %s does not match %s, run the test with -update if intended", req.endpoint(), golden)
+		_ = resp.Body.Close()
+	}
+
+	if recorder != nil {
+		require.NoError(t, recorder.Save(golden))
+	}
+}
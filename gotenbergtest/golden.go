@@ -0,0 +1,309 @@
+package gotenbergtest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+var errNoInteraction = errors.New("no recorded interaction matches request")
+
+// Headers left out of recordings, since they change between runs without changing the request.
+// nolint: gochecknoglobals
+var volatileHeaders = map[string]bool{
+	"Content-Length":  true,
+	"Content-Type":    true,
+	"User-Agent":      true,
+	"Accept-Encoding": true,
+	"Date":            true,
+}
+
+const redacted = "REDACTED"
+
+// RecordedFile is a file of a recorded request, identified by the hash of its content.
+type RecordedFile struct {
+	Name        string `json:"name"`
+	ContentType string `json:"contentType"`
+	Size        int    `json:"size"`
+	SHA256      string `json:"sha256"`
+}
+
+// RecordedRequest is the canonical form of a request: volatile headers such as the multipart boundary
+// are left out, the Authorization header is redacted, and files are sorted by name.
+type RecordedRequest struct {
+	Method string            `json:"method"`
+	Path   string            `json:"path"`
+	Header map[string]string `json:"header,omitempty"`
+	Fields map[string]string `json:"fields,omitempty"`
+	Files  []RecordedFile    `json:"files,omitempty"`
+}
+
+// RecordedResponse is a response as returned by the server.
+type RecordedResponse struct {
+	Status int               `json:"status"`
+	Header map[string]string `json:"header,omitempty"`
+	Body   []byte            `json:"body,omitempty"`
+}
+
+// Interaction is a request and the response it received.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// Golden is the content of a golden file. Its JSON encoding is stable, so that golden files can be
+// compared and reviewed like code.
+type Golden struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// CanonicalRequest reads the body of req and returns its canonical form. The body is replaced so that
+// the request can still be sent.
+func CanonicalRequest(req *http.Request) (RecordedRequest, error) {
+	rec := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Header: canonicalHeader(req.Header),
+	}
+
+	if req.Body == nil {
+		return rec, nil
+	}
+
+	data, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return rec, fmt.Errorf("reading request body: %w", err)
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.ContentLength = int64(len(data))
+
+	mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		return rec, nil //nolint:nilerr // only multipart bodies are described
+	}
+
+	mr := multipart.NewReader(bytes.NewReader(data), params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return rec, fmt.Errorf("reading multipart form: %w", err)
+		}
+
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return rec, fmt.Errorf("reading multipart form: %w", err)
+		}
+
+		if part.FileName() == "" {
+			if rec.Fields == nil {
+				rec.Fields = make(map[string]string)
+			}
+			rec.Fields[part.FormName()] = string(content)
+
+			continue
+		}
+
+		sum := sha256.Sum256(content)
+		rec.Files = append(rec.Files, RecordedFile{
+			Name:        part.FileName(),
+			ContentType: part.Header.Get("Content-Type"),
+			Size:        len(content),
+			SHA256:      hex.EncodeToString(sum[:]),
+		})
+	}
+
+	sort.Slice(rec.Files, func(i, j int) bool {
+		return rec.Files[i].Name < rec.Files[j].Name
+	})
+
+	return rec, nil
+}
+
+func canonicalHeader(h http.Header) map[string]string {
+	header := make(map[string]string)
+	for key, values := range h {
+		key = http.CanonicalHeaderKey(key)
+		if volatileHeaders[key] || len(values) == 0 {
+			continue
+		}
+
+		header[key] = values[0]
+		if key == "Authorization" {
+			header[key] = redacted
+		}
+	}
+
+	if len(header) == 0 {
+		return nil
+	}
+
+	return header
+}
+
+// Recorder is an http.RoundTripper which sends requests with another RoundTripper and records them
+// with their responses. Pass it to NewClient with &http.Client{Transport: recorder}.
+type Recorder struct {
+	next http.RoundTripper
+
+	mu     sync.Mutex
+	golden Golden
+}
+
+// NewRecorder creates a Recorder which sends requests with next, or http.DefaultTransport if nil.
+func NewRecorder(next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &Recorder{next: next}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec, err := CanonicalRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := make(map[string]string)
+	for key, values := range resp.Header {
+		if key != "Date" && len(values) > 0 {
+			header[key] = values[0]
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.golden.Interactions = append(r.golden.Interactions, Interaction{
+		Request:  rec,
+		Response: RecordedResponse{Status: resp.StatusCode, Header: header, Body: body},
+	})
+
+	return resp, nil
+}
+
+// Golden returns the interactions recorded so far.
+func (r *Recorder) Golden() Golden {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return Golden{Interactions: append([]Interaction(nil), r.golden.Interactions...)}
+}
+
+// Save writes the recorded interactions to a golden file.
+func (r *Recorder) Save(fpath string) error {
+	data, err := json.MarshalIndent(r.Golden(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal golden file: %w", err)
+	}
+
+	if err = os.WriteFile(fpath, append(data, '\n'), 0o644); err != nil { //nolint:gosec // golden files are not secret
+		return fmt.Errorf("writing %s: %w", fpath, err)
+	}
+
+	return nil
+}
+
+// Replayer is an http.RoundTripper which answers requests with the responses of a golden file,
+// without sending them. A request which matches no recorded one fails, which makes any change in
+// the fields, headers or documents a client sends visible.
+type Replayer struct {
+	mu     sync.Mutex
+	golden Golden
+	used   []bool
+}
+
+// NewReplayer creates a Replayer which answers with the responses of golden.
+func NewReplayer(golden Golden) *Replayer {
+	return &Replayer{golden: golden, used: make([]bool, len(golden.Interactions))}
+}
+
+// LoadReplayer creates a Replayer from a golden file written by Recorder.Save.
+func LoadReplayer(fpath string) (*Replayer, error) {
+	data, err := os.ReadFile(fpath)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", fpath, err)
+	}
+
+	var golden Golden
+	if err = json.Unmarshal(data, &golden); err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %w", fpath, err)
+	}
+
+	return NewReplayer(golden), nil
+}
+
+// RoundTrip answers with the response of the first unused interaction whose request matches req.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec, err := CanonicalRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.golden.Interactions {
+		if r.used[i] || !reflect.DeepEqual(interaction.Request, rec) {
+			continue
+		}
+
+		r.used[i] = true
+
+		header := make(http.Header, len(interaction.Response.Header))
+		for key, value := range interaction.Response.Header {
+			header.Set(key, value)
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode:    interaction.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	got, _ := json.MarshalIndent(rec, "", "  ")
+
+	return nil, fmt.Errorf("%w:\n%s", errNoInteraction, got)
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = http.RoundTripper(new(Recorder))
+	_ = http.RoundTripper(new(Replayer))
+)
@@ -0,0 +1,82 @@
+package gotenbergtest_test
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8"
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+	"github.com/starwalkn/gotenberg-go-client/v8/gotenbergtest"
+)
+
+func newGoldenRequest(t *testing.T, landscape bool) *gotenberg.HTMLRequest {
+	t.Helper()
+
+	index, err := document.FromString("index.html", "<html>Hello</html>")
+	require.NoError(t, err)
+	style, err := document.FromString("style.css", "body { color: red; }")
+	require.NoError(t, err)
+
+	req := gotenberg.NewHTMLRequest(index)
+	req.Assets(style)
+	req.Trace("golden")
+	req.UseBasicAuth("foo", "bar")
+	if landscape {
+		req.Landscape()
+	}
+
+	return req
+}
+
+func TestRecordReplay(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	recorder := gotenbergtest.NewRecorder(srv.Client().Transport)
+	c, err := gotenberg.NewClient(srv.URL, &http.Client{Transport: recorder})
+	require.NoError(t, err)
+
+	resp, err := c.Send(context.Background(), newGoldenRequest(t, true))
+	require.NoError(t, err)
+	recorded := readAll(t, resp)
+
+	golden := recorder.Golden()
+	require.Len(t, golden.Interactions, 1)
+
+	rec := golden.Interactions[0].Request
+	assert.Equal(t, "/forms/chromium/convert/html", rec.Path)
+	assert.Equal(t, map[string]string{"landscape": "true"}, rec.Fields)
+	assert.Equal(t, "REDACTED", rec.Header["Authorization"])
+	require.Len(t, rec.Files, 2)
+	assert.Equal(t, "index.html", rec.Files[0].Name)
+	assert.Equal(t, "style.css", rec.Files[1].Name)
+
+	fpath := filepath.Join(t.TempDir(), "golden.json")
+	require.NoError(t, recorder.Save(fpath))
+
+	replayer, err := gotenbergtest.LoadReplayer(fpath)
+	require.NoError(t, err)
+	c, err = gotenberg.NewClient("http://gotenberg", &http.Client{Transport: replayer})
+	require.NoError(t, err)
+
+	resp, err = c.Send(context.Background(), newGoldenRequest(t, true))
+	require.NoError(t, err)
+	assert.Equal(t, recorded, readAll(t, resp))
+
+	// Each interaction is replayed once, and a different request matches none.
+	_, err = c.Send(context.Background(), newGoldenRequest(t, true))
+	require.Error(t, err)
+
+	replayer, err = gotenbergtest.LoadReplayer(fpath)
+	require.NoError(t, err)
+	c, err = gotenberg.NewClient("http://gotenberg", &http.Client{Transport: replayer})
+	require.NoError(t, err)
+
+	_, err = c.Send(context.Background(), newGoldenRequest(t, false))
+	assert.ErrorContains(t, err, "no recorded interaction matches request")
+}
@@ -0,0 +1,112 @@
+package gotenbergtest
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"sort"
+)
+
+// PDF returns a minimal valid PDF document with the given number of blank A4 pages.
+func PDF(pages int) []byte {
+	if pages < 1 {
+		pages = 1
+	}
+
+	var b bytes.Buffer
+	offsets := make([]int, 0, pages+2)
+
+	object := func(format string, args ...any) {
+		offsets = append(offsets, b.Len())
+		fmt.Fprintf(&b, "%d 0 obj\n"+format+"\nendobj\n", append([]any{len(offsets)}, args...)...)
+	}
+
+	b.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := make([]string, pages)
+	for i := range kids {
+		kids[i] = fmt.Sprintf("%d 0 R", i+3)
+	}
+	object("<< /Type /Pages /Kids %v /Count %d >>", kids, pages)
+
+	for range pages {
+		object("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] >>")
+	}
+
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return b.Bytes()
+}
+
+// PNG returns a white PNG image of the given size.
+func PNG(width, height int) []byte {
+	var b bytes.Buffer
+	_ = png.Encode(&b, whiteImage(width, height))
+
+	return b.Bytes()
+}
+
+// JPEG returns a white JPEG image of the given size.
+func JPEG(width, height int) []byte {
+	var b bytes.Buffer
+	_ = jpeg.Encode(&b, whiteImage(width, height), nil)
+
+	return b.Bytes()
+}
+
+// WebP returns a 1x1 WebP image. The standard library has no WebP encoder, so its size is fixed.
+func WebP() []byte {
+	data, _ := base64.StdEncoding.DecodeString("UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA==")
+
+	return data
+}
+
+// Zip returns a zip archive of the given files, sorted by name.
+func Zip(files map[string][]byte) []byte {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+
+	for _, name := range names {
+		w, _ := zw.Create(name)
+		_, _ = w.Write(files[name])
+	}
+
+	_ = zw.Close()
+
+	return b.Bytes()
+}
+
+func whiteImage(width, height int) image.Image {
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.White)
+		}
+	}
+
+	return img
+}
@@ -0,0 +1,598 @@
+// Package gotenbergtest provides a fake Gotenberg server for tests, so that code using the client
+// can be tested without running Gotenberg.
+package gotenbergtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Routes served by the fake server.
+const (
+	RouteHealth             = "/health"
+	RouteVersion            = "/version"
+	RouteURLConvert         = "/forms/chromium/convert/url"
+	RouteHTMLConvert        = "/forms/chromium/convert/html"
+	RouteMarkdownConvert    = "/forms/chromium/convert/markdown"
+	RouteURLScreenshot      = "/forms/chromium/screenshot/url"
+	RouteHTMLScreenshot     = "/forms/chromium/screenshot/html"
+	RouteMarkdownScreenshot = "/forms/chromium/screenshot/markdown"
+	RouteOfficeConvert      = "/forms/libreoffice/convert"
+	RouteMerge              = "/forms/pdfengines/merge"
+	RouteSplit              = "/forms/pdfengines/split"
+	RouteMetadataRead       = "/forms/pdfengines/metadata/read"
+	RouteMetadataWrite      = "/forms/pdfengines/metadata/write"
+	RouteFlatten            = "/forms/pdfengines/flatten"
+)
+
+var errInvalidForm = errors.New("Invalid form data") //nolint:stylecheck // Gotenberg's own message.
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Header http.Header
+	// Fields holds the form fields.
+	Fields map[string]string
+	// Files maps the filename of each uploaded file to its content.
+	Files map[string][]byte
+	// FileTypes maps the filename of each uploaded file to the Content-Type of its part.
+	FileTypes map[string]string
+}
+
+// Response is a canned response which replaces the output the server generates for a route.
+type Response struct {
+	// Status defaults to 200 OK.
+	Status      int
+	ContentType string
+	Body        []byte
+	Header      http.Header
+}
+
+// Server is a fake Gotenberg server. It validates multipart forms like Gotenberg, records the requests
+// it receives and replies with small valid outputs: PDFs, zip archives of PDFs, images or JSON.
+// Webhooks are called like Gotenberg does, right after replying 204 No Content.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	requests    []Request
+	responses   map[string]Response
+	delay       time.Duration
+	failures    []int
+	unavailable bool
+
+	webhooks sync.WaitGroup
+}
+
+// NewServer starts a fake Gotenberg server. The caller must Close it.
+func NewServer() *Server {
+	s := &Server{responses: make(map[string]Response)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Close shuts the server down and waits for the webhook calls in progress.
+func (s *Server) Close() {
+	s.Server.Close()
+	s.webhooks.Wait()
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// LastRequest returns the last request received, if any.
+func (s *Server) LastRequest() (Request, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.requests) == 0 {
+		return Request{}, false
+	}
+
+	return s.requests[len(s.requests)-1], true
+}
+
+// Reset forgets the recorded requests and every configured behavior.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = nil
+	s.responses = make(map[string]Response)
+	s.delay = 0
+	s.failures = nil
+	s.unavailable = false
+}
+
+// SetResponse makes the server reply to valid requests to route with resp.
+func (s *Server) SetResponse(route string, resp Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses[route] = resp
+}
+
+// SetDelay delays every response, e.g. to test timeouts.
+func (s *Server) SetDelay(delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.delay = delay
+}
+
+// FailNext makes the next n requests fail with the given status code, e.g. 503 Service Unavailable.
+func (s *Server) FailNext(n, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for range n {
+		s.failures = append(s.failures, status)
+	}
+}
+
+// SetUnavailable makes every route, including the health check, reply 503 Service Unavailable until it is unset.
+func (s *Server) SetUnavailable(unavailable bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.unavailable = unavailable
+}
+
+// behavior returns what the server must do with the current request.
+func (s *Server) behavior(route string) (delay time.Duration, failure int, resp *Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.unavailable {
+		failure = http.StatusServiceUnavailable
+	} else if len(s.failures) > 0 {
+		failure, s.failures = s.failures[0], s.failures[1:]
+	}
+
+	if canned, ok := s.responses[route]; ok {
+		resp = &canned
+	}
+
+	return s.delay, failure, resp
+}
+
+func (s *Server) record(req Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, req)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	req, parseErr := parseRequest(r)
+	s.record(req)
+
+	delay, failure, canned := s.behavior(r.URL.Path)
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if failure != 0 && r.URL.Path == RouteHealth {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(failure)
+		_ = json.NewEncoder(w).Encode(map[string]any{"status": "down", "details": map[string]any{}})
+
+		return
+	}
+
+	if failure != 0 {
+		http.Error(w, http.StatusText(failure), failure)
+
+		return
+	}
+
+	switch r.URL.Path {
+	case RouteHealth:
+		writeJSON(w, map[string]any{"status": "up", "details": map[string]any{}})
+
+		return
+	case RouteVersion:
+		_, _ = io.WriteString(w, "8.0.0-gotenbergtest")
+
+		return
+	}
+
+	rt, ok := routes[r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	trace := r.Header.Get("Gotenberg-Trace")
+	if trace == "" {
+		trace = fmt.Sprintf("gotenbergtest-%d", time.Now().UnixNano())
+	}
+
+	err := parseErr
+	if err == nil {
+		err = rt.validate(req)
+	}
+
+	out := output{}
+	if err == nil {
+		out = rt.output(req)
+		if canned != nil {
+			out = output{status: canned.Status, contentType: canned.ContentType, body: canned.Body, header: canned.Header, ext: out.ext}
+		}
+	}
+
+	if hookURL := r.Header.Get("Gotenberg-Webhook-Url"); hookURL != "" {
+		w.Header().Set("Gotenberg-Trace", trace)
+		w.WriteHeader(http.StatusNoContent)
+		s.callWebhook(req, trace, out, err)
+
+		return
+	}
+
+	w.Header().Set("Gotenberg-Trace", trace)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	out.write(w, outputFilename(req, trace))
+}
+
+func parseRequest(r *http.Request) (Request, error) {
+	req := Request{
+		Method:    r.Method,
+		Path:      r.URL.Path,
+		Header:    r.Header.Clone(),
+		Fields:    make(map[string]string),
+		Files:     make(map[string][]byte),
+		FileTypes: make(map[string]string),
+	}
+
+	if r.Method != http.MethodPost {
+		return req, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		return req, fmt.Errorf("%w: expected a multipart form", errInvalidForm)
+	}
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return req, fmt.Errorf("%w: %w", errInvalidForm, err)
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return req, nil
+		}
+		if err != nil {
+			return req, fmt.Errorf("%w: %w", errInvalidForm, err)
+		}
+
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return req, fmt.Errorf("%w: %w", errInvalidForm, err)
+		}
+
+		if fname := part.FileName(); fname != "" {
+			if _, ok := req.Files[fname]; ok {
+				return req, fmt.Errorf("%w: duplicate file %s", errInvalidForm, fname)
+			}
+
+			req.Files[fname] = data
+			req.FileTypes[fname] = part.Header.Get("Content-Type")
+
+			continue
+		}
+
+		req.Fields[part.FormName()] = string(data)
+	}
+}
+
+// output is what a route generates for a valid request.
+type output struct {
+	status      int
+	contentType string
+	ext         string
+	body        []byte
+	header      http.Header
+}
+
+func (out output) write(w http.ResponseWriter, filename string) {
+	for key, values := range out.header {
+		w.Header()[key] = values
+	}
+
+	if out.contentType != "" {
+		w.Header().Set("Content-Type", out.contentType)
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+out.ext))
+	w.Header().Set("Content-Length", strconv.Itoa(len(out.body)))
+
+	status := out.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	w.WriteHeader(status)
+	_, _ = w.Write(out.body)
+}
+
+func outputFilename(req Request, trace string) string {
+	if fname := req.Header.Get("Gotenberg-Output-Filename"); fname != "" {
+		return fname
+	}
+
+	return trace
+}
+
+func (s *Server) callWebhook(req Request, trace string, out output, failure error) {
+	method, target := req.Header.Get("Gotenberg-Webhook-Method"), req.Header.Get("Gotenberg-Webhook-Url")
+	contentType, body := out.contentType, out.body
+
+	if failure != nil {
+		method, target = req.Header.Get("Gotenberg-Webhook-Error-Method"), req.Header.Get("Gotenberg-Webhook-Error-Url")
+		contentType = "application/json"
+		body, _ = json.Marshal(map[string]any{"status": http.StatusBadRequest, "message": failure.Error()})
+	}
+
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	extra := make(map[string]string)
+	_ = json.Unmarshal([]byte(req.Header.Get("Gotenberg-Webhook-Extra-Http-Headers")), &extra)
+
+	s.webhooks.Add(1)
+
+	go func() {
+		defer s.webhooks.Done()
+
+		hook, err := http.NewRequest(method, target, bytes.NewReader(body))
+		if err != nil {
+			return
+		}
+
+		for key, value := range extra {
+			hook.Header.Set(key, value)
+		}
+
+		hook.Header.Set("Content-Type", contentType)
+		hook.Header.Set("Gotenberg-Trace", trace)
+		if failure == nil {
+			hook.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", outputFilename(req, trace)+out.ext))
+		}
+
+		if resp, err := http.DefaultClient.Do(hook); err == nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+	}()
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// route validates the forms of a Gotenberg route and generates its output.
+type route struct {
+	validate func(req Request) error
+	output   func(req Request) output
+}
+
+// nolint: gochecknoglobals
+var routes = map[string]route{
+	RouteURLConvert:         {validate: requireFields("url"), output: pdfOutput},
+	RouteHTMLConvert:        {validate: requireFiles("index.html"), output: pdfOutput},
+	RouteMarkdownConvert:    {validate: validateMarkdown, output: pdfOutput},
+	RouteURLScreenshot:      {validate: requireFields("url"), output: screenshotOutput},
+	RouteHTMLScreenshot:     {validate: requireFiles("index.html"), output: screenshotOutput},
+	RouteMarkdownScreenshot: {validate: validateMarkdown, output: screenshotOutput},
+	RouteOfficeConvert:      {validate: requireAnyFile(), output: officeOutput},
+	RouteMerge:              {validate: requireAnyFile(".pdf"), output: pdfOutput},
+	RouteSplit:              {validate: validateSplit, output: splitOutput},
+	RouteMetadataRead:       {validate: requireAnyFile(".pdf"), output: metadataOutput},
+	RouteMetadataWrite:      {validate: validateMetadataWrite, output: perFileOutput},
+	RouteFlatten:            {validate: requireAnyFile(".pdf"), output: perFileOutput},
+}
+
+func requireFields(names ...string) func(req Request) error {
+	return func(req Request) error {
+		for _, name := range names {
+			if req.Fields[name] == "" {
+				return fmt.Errorf("%w: form field '%s' is required", errInvalidForm, name)
+			}
+		}
+
+		return nil
+	}
+}
+
+func requireFiles(names ...string) func(req Request) error {
+	return func(req Request) error {
+		for _, name := range names {
+			if _, ok := req.Files[name]; !ok {
+				return fmt.Errorf("%w: form file '%s' is required", errInvalidForm, name)
+			}
+		}
+
+		return nil
+	}
+}
+
+// requireAnyFile requires at least one file, or one file with each of the extensions.
+func requireAnyFile(exts ...string) func(req Request) error {
+	return func(req Request) error {
+		if len(exts) == 0 && len(req.Files) > 0 {
+			return nil
+		}
+
+		for fname := range req.Files {
+			for _, ext := range exts {
+				if strings.EqualFold(path.Ext(fname), ext) {
+					return nil
+				}
+			}
+		}
+
+		return fmt.Errorf("%w: no form file found for extensions: %v", errInvalidForm, exts)
+	}
+}
+
+func validateMarkdown(req Request) error {
+	if err := requireFiles("index.html")(req); err != nil {
+		return err
+	}
+
+	return requireAnyFile(".md")(req)
+}
+
+func validateSplit(req Request) error {
+	if err := requireFields("splitMode", "splitSpan")(req); err != nil {
+		return err
+	}
+
+	if mode := req.Fields["splitMode"]; mode != "intervals" && mode != "pages" {
+		return fmt.Errorf("%w: form field 'splitMode' is invalid (got '%s')", errInvalidForm, mode)
+	}
+
+	return requireAnyFile(".pdf")(req)
+}
+
+func validateMetadataWrite(req Request) error {
+	if err := requireFields("metadata")(req); err != nil {
+		return err
+	}
+
+	if !json.Valid([]byte(req.Fields["metadata"])) {
+		return fmt.Errorf("%w: form field 'metadata' is invalid", errInvalidForm)
+	}
+
+	return requireAnyFile(".pdf")(req)
+}
+
+func pdfOutput(Request) output {
+	return output{contentType: "application/pdf", ext: ".pdf", body: PDF(1)}
+}
+
+func screenshotOutput(req Request) output {
+	// The client sends floats, e.g. "800.000000".
+	width, _ := strconv.ParseFloat(req.Fields["width"], 64)
+	height, _ := strconv.ParseFloat(req.Fields["height"], 64)
+
+	// Keep images small, whatever the requested viewport.
+	width, height = min(max(width, 1), 16), min(max(height, 1), 16)
+
+	switch req.Fields["format"] {
+	case "jpeg":
+		return output{contentType: "image/jpeg", ext: ".jpeg", body: JPEG(int(width), int(height))}
+	case "webp":
+		return output{contentType: "image/webp", ext: ".webp", body: WebP()}
+	default:
+		return output{contentType: "image/png", ext: ".png", body: PNG(int(width), int(height))}
+	}
+}
+
+// perFileOutput returns one PDF per file, or a zip archive of them if there are several.
+func perFileOutput(req Request) output {
+	if len(req.Files) == 1 {
+		return pdfOutput(req)
+	}
+
+	files := make(map[string][]byte, len(req.Files))
+	for fname := range req.Files {
+		files[strings.TrimSuffix(fname, path.Ext(fname))+".pdf"] = PDF(1)
+	}
+
+	return output{contentType: "application/zip", ext: ".zip", body: Zip(files)}
+}
+
+func officeOutput(req Request) output {
+	if req.Fields["merge"] == "true" {
+		return pdfOutput(req)
+	}
+
+	return perFileOutput(req)
+}
+
+// nolint: gochecknoglobals
+var pageCountRe = regexp.MustCompile(`/Count\s+(\d+)`)
+
+// splitOutput splits each PDF by intervals of pages, or extracts its page span, as a zip archive.
+func splitOutput(req Request) output {
+	span, _ := strconv.Atoi(req.Fields["splitSpan"])
+	span = max(span, 1)
+
+	files := make(map[string][]byte)
+
+	for fname, data := range req.Files {
+		base := strings.TrimSuffix(fname, path.Ext(fname))
+
+		if req.Fields["splitMode"] == "pages" {
+			files[base+"_0.pdf"] = PDF(1)
+
+			continue
+		}
+
+		pages := 1
+		if m := pageCountRe.FindSubmatch(data); m != nil {
+			pages, _ = strconv.Atoi(string(m[1]))
+		}
+
+		for i := 0; i*span < pages; i++ {
+			files[fmt.Sprintf("%s_%d.pdf", base, i)] = PDF(min(span, pages-i*span))
+		}
+	}
+
+	if len(files) == 1 && req.Fields["splitUnify"] == "true" {
+		return pdfOutput(req)
+	}
+
+	return output{contentType: "application/zip", ext: ".zip", body: Zip(files)}
+}
+
+func metadataOutput(req Request) output {
+	metadata := make(map[string]map[string]any, len(req.Files))
+	for fname := range req.Files {
+		metadata[fname] = map[string]any{"Producer": "gotenbergtest"}
+	}
+
+	body, _ := json.Marshal(metadata)
+
+	return output{contentType: "application/json", body: body}
+}
@@ -0,0 +1,173 @@
+package gotenbergtest_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8"
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+	"github.com/starwalkn/gotenberg-go-client/v8/gotenbergtest"
+)
+
+func newClient(t *testing.T, srv *gotenbergtest.Server, opts ...gotenberg.ClientOption) *gotenberg.Client {
+	t.Helper()
+
+	c, err := gotenberg.NewClient(srv.URL, srv.Client(), opts...)
+	require.NoError(t, err)
+
+	return c
+}
+
+func readAll(t *testing.T, resp *http.Response) []byte {
+	t.Helper()
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestServerRecordsRequests(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	index, err := document.FromString("index.html", "<html>Hello</html>")
+	require.NoError(t, err)
+
+	req := gotenberg.NewHTMLRequest(index)
+	req.Landscape()
+	req.OutputFilename("hello")
+	req.Trace("trace-1")
+
+	resp, err := newClient(t, srv).Send(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `attachment; filename="hello.pdf"`, resp.Header.Get("Content-Disposition"))
+	assert.True(t, bytes.HasPrefix(readAll(t, resp), []byte("%PDF-")))
+
+	last, ok := srv.LastRequest()
+	require.True(t, ok)
+	assert.Equal(t, gotenbergtest.RouteHTMLConvert, last.Path)
+	assert.Equal(t, "true", last.Fields["landscape"])
+	assert.Equal(t, "<html>Hello</html>", string(last.Files["index.html"]))
+	assert.Equal(t, "trace-1", last.Header.Get("Gotenberg-Trace"))
+}
+
+func TestServerValidatesForms(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	doc, err := document.FromString("notes.md", "# Notes")
+	require.NoError(t, err)
+
+	// The client's own validation would catch the missing index.html first.
+	c := newClient(t, srv, gotenberg.WithoutValidation())
+
+	resp, err := c.Send(context.Background(), gotenberg.NewMergeRequest(doc))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, string(readAll(t, resp)), "Invalid form data")
+}
+
+func TestServerOutputs(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	c := newClient(t, srv)
+
+	pdf, err := document.FromBytes("doc.pdf", gotenbergtest.PDF(5))
+	require.NoError(t, err)
+
+	split := gotenberg.NewSplitIntervalsRequest(pdf)
+	split.SplitSpan(2)
+
+	resp, err := c.Send(context.Background(), split)
+	require.NoError(t, err)
+
+	archive := readAll(t, resp)
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+	assert.Len(t, zr.File, 3)
+
+	url := gotenberg.NewURLRequest("https://example.com")
+	url.ScreenshotWidth(8)
+	url.ScreenshotHeight(4)
+
+	resp, err = c.Screenshot(context.Background(), url)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(readAll(t, resp)))
+	require.NoError(t, err)
+	assert.Equal(t, 8, img.Bounds().Dx())
+	assert.Equal(t, 4, img.Bounds().Dy())
+}
+
+func TestServerFailures(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	c := newClient(t, srv)
+	req := gotenberg.NewURLRequest("https://example.com")
+
+	srv.FailNext(1, http.StatusServiceUnavailable)
+
+	resp, err := c.Send(context.Background(), req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	srv.SetResponse(gotenbergtest.RouteURLConvert, gotenbergtest.Response{ContentType: "application/pdf", Body: []byte("%PDF-canned")})
+
+	resp, err = c.Send(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-canned", string(readAll(t, resp)))
+
+	srv.SetDelay(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = c.Send(ctx, req)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	assert.Len(t, srv.Requests(), 3)
+}
+
+func TestServerWebhook(t *testing.T) {
+	srv := gotenbergtest.NewServer()
+	defer srv.Close()
+
+	hook := httptest.NewUnstartedServer(nil)
+	jobs, err := gotenberg.NewJobs("http://" + hook.Listener.Addr().String() + "/hook")
+	require.NoError(t, err)
+	hook.Config.Handler = jobs
+	hook.Start()
+	defer hook.Close()
+
+	c := newClient(t, srv, gotenberg.WithJobs(jobs))
+
+	req := gotenberg.NewURLRequest("https://example.com")
+	req.OutputFilename("example")
+
+	job, err := c.Submit(context.Background(), req)
+	require.NoError(t, err)
+
+	res, err := job.Wait(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "example.pdf", res.Filename)
+	assert.True(t, bytes.HasPrefix(res.Data, []byte("%PDF-")))
+}
@@ -0,0 +1,220 @@
+package gotenberg
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+var (
+	errInvalidSlot = errors.New("invalid header/footer slot")
+	errNilLogo     = errors.New("header/footer logo is nil")
+)
+
+// Placeholders which Chromium replaces when it prints a header or a footer.
+const (
+	PlaceholderPageNumber = "{pageNumber}"
+	PlaceholderTotalPages = "{totalPages}"
+	PlaceholderDate       = "{date}"
+	PlaceholderTitle      = "{title}"
+	PlaceholderURL        = "{url}"
+)
+
+// HeaderFooterSlot is a position in a header or a footer.
+type HeaderFooterSlot int
+
+const (
+	SlotLeft HeaderFooterSlot = iota
+	SlotCenter
+	SlotRight
+)
+
+func (s HeaderFooterSlot) class() string {
+	switch s {
+	case SlotCenter:
+		return "center"
+	case SlotRight:
+		return "right"
+	default:
+		return "left"
+	}
+}
+
+// nolint: gochecknoglobals
+var placeholderReplacer = strings.NewReplacer(
+	PlaceholderPageNumber, `<span class="pageNumber"></span>`,
+	PlaceholderTotalPages, `<span class="totalPages"></span>`,
+	PlaceholderDate, `<span class="date"></span>`,
+	PlaceholderTitle, `<span class="title"></span>`,
+	PlaceholderURL, `<span class="url"></span>`,
+)
+
+type headerFooterLogo struct {
+	img    document.Document
+	height Length
+}
+
+// HeaderFooter builds the self-contained HTML document that Chromium requires for headers and footers.
+// Text in each slot may contain placeholders, e.g. "Page {pageNumber} of {totalPages}".
+type HeaderFooter struct {
+	slots   [3]string
+	logos   [3]*headerFooterLogo
+	font    string
+	size    Length
+	color   string
+	padding Length
+	// err is the first invalid setting, reported when the HTML is generated.
+	err error
+}
+
+// NewHeaderFooter creates a header or footer builder with a 9pt sans-serif font.
+func NewHeaderFooter() *HeaderFooter {
+	return &HeaderFooter{
+		font:    "sans-serif",
+		size:    Length{Value: 9, Unit: PT},
+		color:   "#000",
+		padding: Length{Value: 10, Unit: MM},
+	}
+}
+
+// Left sets the text of the left slot.
+func (hf *HeaderFooter) Left(text string) {
+	hf.slots[SlotLeft] = text
+}
+
+// Center sets the text of the center slot.
+func (hf *HeaderFooter) Center(text string) {
+	hf.slots[SlotCenter] = text
+}
+
+// Right sets the text of the right slot.
+func (hf *HeaderFooter) Right(text string) {
+	hf.slots[SlotRight] = text
+}
+
+// Logo places an image before the text of the given slot. The image is inlined as a data URI,
+// since Chromium does not load external resources in headers and footers. An invalid slot or a nil
+// image is reported by HTML, Header and Footer.
+func (hf *HeaderFooter) Logo(slot HeaderFooterSlot, img document.Document, height Length) {
+	if slot < SlotLeft || slot > SlotRight {
+		if hf.err == nil {
+			hf.err = fmt.Errorf("%w: %d", errInvalidSlot, slot)
+		}
+
+		return
+	}
+
+	if img == nil {
+		if hf.err == nil {
+			hf.err = fmt.Errorf("%w: slot %d", errNilLogo, slot)
+		}
+
+		return
+	}
+
+	hf.logos[slot] = &headerFooterLogo{img: img, height: height}
+}
+
+// Font sets the CSS font family and the font size. Chromium's default size is too small to be readable.
+func (hf *HeaderFooter) Font(family string, size Length) {
+	hf.font = family
+	hf.size = size
+}
+
+// Color sets the CSS text color.
+func (hf *HeaderFooter) Color(color string) {
+	hf.color = color
+}
+
+// Padding sets the horizontal distance between the slots and the page edges.
+func (hf *HeaderFooter) Padding(padding Length) {
+	hf.padding = padding
+}
+
+// Header returns the header.html document to pass to Header.
+func (hf *HeaderFooter) Header() (document.Document, error) {
+	return hf.document("header.html")
+}
+
+// Footer returns the footer.html document to pass to Footer.
+func (hf *HeaderFooter) Footer() (document.Document, error) {
+	return hf.document("footer.html")
+}
+
+// HTML returns the generated HTML.
+func (hf *HeaderFooter) HTML() (string, error) {
+	if hf.err != nil {
+		return "", hf.err
+	}
+
+	for name, value := range map[string]string{"font": hf.font, "color": hf.color} {
+		if strings.ContainsAny(value, ";{}<>\\") {
+			return "", fmt.Errorf("header/footer %s %q contains forbidden characters", name, value)
+		}
+	}
+
+	var b strings.Builder
+
+	b.WriteString("<!doctype html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<style>\n")
+	b.WriteString("html, body { margin: 0; padding: 0; }\n")
+	fmt.Fprintf(&b, "body { width: 100%%; font-family: %s; font-size: %s; color: %s; "+
+		"-webkit-print-color-adjust: exact; }\n", hf.font, hf.size, hf.color)
+	fmt.Fprintf(&b, ".row { display: flex; align-items: center; margin: 0 %s; }\n", hf.padding)
+	b.WriteString(".slot { flex: 1; white-space: nowrap; }\n")
+	b.WriteString(".left { text-align: left; }\n.center { text-align: center; }\n.right { text-align: right; }\n")
+	b.WriteString("img { vertical-align: middle; }\n")
+	b.WriteString("</style>\n</head>\n<body>\n<div class=\"row\">\n")
+
+	for slot := SlotLeft; slot <= SlotRight; slot++ {
+		fmt.Fprintf(&b, "<div class=\"slot %s\">", slot.class())
+
+		if logo := hf.logos[slot]; logo != nil {
+			img, err := dataURI(logo.img)
+			if err != nil {
+				return "", err
+			}
+
+			fmt.Fprintf(&b, "<img src=\"%s\" style=\"height: %s\"> ", img, logo.height)
+		}
+
+		b.WriteString(placeholderReplacer.Replace(html.EscapeString(hf.slots[slot])))
+		b.WriteString("</div>\n")
+	}
+
+	b.WriteString("</div>\n</body>\n</html>\n")
+
+	return b.String(), nil
+}
+
+func (hf *HeaderFooter) document(filename string) (document.Document, error) {
+	content, err := hf.HTML()
+	if err != nil {
+		return nil, err
+	}
+
+	return document.FromString(filename, content)
+}
+
+func dataURI(doc document.Document) (string, error) {
+	in, err := doc.Reader()
+	if err != nil {
+		return "", fmt.Errorf("getting %s reader: %w", doc.Filename(), err)
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", doc.Filename(), err)
+	}
+
+	contentType := document.DetectContentType(doc, data)
+
+	return fmt.Sprintf("data:%s;base64,%s", contentType, base64.StdEncoding.EncodeToString(data)), nil
+}
@@ -0,0 +1,72 @@
+package gotenberg
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+	"github.com/starwalkn/gotenberg-go-client/v8/test"
+)
+
+func TestHeaderFooter(t *testing.T) {
+	logo, err := document.FromPath("img.gif", test.HTMLTestFilePath(t, "img.gif"))
+	require.NoError(t, err)
+
+	hf := NewHeaderFooter()
+	hf.Left("ACME <Corp> & Co")
+	hf.Center("Page {pageNumber} of {totalPages}")
+	hf.Right("{date}")
+	hf.Logo(SlotLeft, logo, Length{Value: 8, Unit: MM})
+	hf.Font("'Helvetica Neue', Arial", Length{Value: 10, Unit: PT})
+
+	footer, err := hf.Footer()
+	require.NoError(t, err)
+	assert.Equal(t, "footer.html", footer.Filename())
+
+	r, err := footer.Reader()
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	out := string(content)
+	assert.Contains(t, out, "ACME &lt;Corp&gt; &amp; Co")
+	assert.Contains(t, out, `Page <span class="pageNumber"></span> of <span class="totalPages"></span>`)
+	assert.Contains(t, out, `<span class="date"></span>`)
+	assert.Contains(t, out, `<img src="data:image/gif;base64,`)
+	assert.Contains(t, out, "font-size: 10pt")
+	assert.Contains(t, out, "height: 8mm")
+
+	req := NewURLRequest("https://example.com")
+	req.Footer(footer)
+	assert.Equal(t, footer, req.formDocuments()["footer.html"])
+}
+
+func TestHeaderFooterInvalidStyle(t *testing.T) {
+	hf := NewHeaderFooter()
+	hf.Color("red; } body { display: none")
+
+	_, err := hf.Header()
+	require.Error(t, err)
+}
+
+func TestHeaderFooterInvalidSlot(t *testing.T) {
+	logo, err := document.FromBytes("logo.png", []byte("\x89PNG\r\n\x1a\n"))
+	require.NoError(t, err)
+
+	hf := NewHeaderFooter()
+	hf.Logo(HeaderFooterSlot(3), logo, Length{Value: 8, Unit: MM})
+
+	_, err = hf.Footer()
+	require.ErrorIs(t, err, errInvalidSlot)
+}
+
+func TestHeaderFooterNilLogo(t *testing.T) {
+	hf := NewHeaderFooter()
+	hf.Logo(SlotLeft, nil, Length{Value: 8, Unit: MM})
+
+	_, err := hf.Header()
+	require.ErrorIs(t, err, errNilLogo)
+}
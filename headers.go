@@ -17,4 +17,6 @@ const (
 	headerWebhookMethod       httpHeader = "Gotenberg-Webhook-Method"
 	headerWebhookErrorMethod  httpHeader = "Gotenberg-Webhook-Error-Method"
 	headerWebhookExtraHeaders httpHeader = "Gotenberg-Webhook-Extra-Http-Headers"
+	headerWebhookEventsURL    httpHeader = "Gotenberg-Webhook-Events-Url"
+	headerWebhookEventsMethod httpHeader = "Gotenberg-Webhook-Events-Method"
 )
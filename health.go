@@ -0,0 +1,56 @@
+package gotenberg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const endpointHealth = "/health"
+
+var errUnhealthy = errors.New("gotenberg is unhealthy")
+
+// HealthStatus is the health of Gotenberg and of its modules, e.g. chromium and libreoffice.
+type HealthStatus struct {
+	// Status is "up" or "down".
+	Status  string                  `json:"status"`
+	Details map[string]HealthDetail `json:"details,omitempty"`
+}
+
+// HealthDetail is the health of a Gotenberg module.
+type HealthDetail struct {
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Health returns the health of Gotenberg. A Gotenberg which is down replies 503 Service Unavailable,
+// in which case the status is returned along with an error.
+func (c *Client) Health(ctx context.Context) (*HealthStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.hostname+endpointHealth, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errSendRequestFailed, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var status HealthStatus
+	if err = json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("decoding health status (%d): %w", resp.StatusCode, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return &status, fmt.Errorf("%w: status %s, %d", errUnhealthy, status.Status, resp.StatusCode)
+	}
+
+	return &status, nil
+}
@@ -52,6 +52,16 @@ func (req *HTMLRequest) Assets(assets ...document.Document) {
 	req.assets = assets
 }
 
+// Validate checks the request options before it is sent.
+func (req *HTMLRequest) Validate() error {
+	v := &validator{}
+	v.document("index.html", req.index)
+	v.documents("assets", req.assets, 0)
+	v.validateChromium(req.chromiumRequest)
+
+	return v.err()
+}
+
 // Compile-time checks to ensure type implements desired interfaces.
 var (
 	_ = multipartRequester(new(HTMLRequest))
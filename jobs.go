@@ -0,0 +1,363 @@
+package gotenberg
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ErrJobTimeout is returned by Job.Wait when the webhook call of a job did not arrive in time.
+var ErrJobTimeout = errors.New("job timed out")
+
+var errNoJobs = errors.New("client has no jobs, see WithJobs")
+
+const (
+	defaultJobTimeout = 10 * time.Minute
+
+	// jobIDHeader carries the correlation ID of a job through the webhook extra headers.
+	jobIDHeader = "Gotenberg-Client-Job-Id"
+)
+
+// JobResult is the resulting file of a job.
+type JobResult struct {
+	// Filename is the output filename, with its extension.
+	Filename string
+	// ContentType is the MIME type of the file.
+	ContentType string
+	// Trace identifies the request in Gotenberg's logs.
+	Trace string
+	// Header holds all headers of the webhook call.
+	Header http.Header
+	// Data is the content of the file.
+	Data []byte
+}
+
+// Job is a request submitted to Gotenberg whose result is delivered to a webhook.
+type Job struct {
+	id       string
+	deadline time.Time
+	trace    string
+
+	once   sync.Once
+	done   chan struct{}
+	result *JobResult
+	err    error
+
+	expire func()
+}
+
+func newJob(id string, deadline time.Time) *Job {
+	return &Job{id: id, deadline: deadline, done: make(chan struct{})}
+}
+
+// ID returns the correlation ID of the job.
+func (j *Job) ID() string {
+	return j.id
+}
+
+// Deadline returns the time after which the job times out.
+func (j *Job) Deadline() time.Time {
+	return j.deadline
+}
+
+// Trace returns the trace which identifies the request in Gotenberg's logs.
+func (j *Job) Trace() string {
+	return j.trace
+}
+
+// Wait waits until the webhook receives the result or the error of the job. Errors reported by
+// Gotenberg are returned as WebhookError. If the job reaches its deadline, ErrJobTimeout is returned.
+func (j *Job) Wait(ctx context.Context) (*JobResult, error) {
+	timer := time.NewTimer(time.Until(j.deadline))
+	defer timer.Stop()
+
+	select {
+	case <-j.done:
+	case <-timer.C:
+		if j.expire != nil {
+			j.expire()
+		}
+		j.complete(nil, ErrJobTimeout)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	<-j.done
+
+	return j.result, j.err
+}
+
+func (j *Job) complete(result *JobResult, err error) {
+	j.once.Do(func() {
+		j.result, j.err = result, err
+		close(j.done)
+	})
+}
+
+// JobStore holds pending jobs until their webhook call arrives. Implementations must be safe for concurrent use.
+//
+// Job.Wait is woken up in process by the Job which Take returns, so Take must return the very Job passed to
+// Add: a store must live in the process which submits the jobs and serves the webhook, and cannot be shared
+// with other instances, e.g. through a database.
+type JobStore interface {
+	// Add stores a pending job.
+	Add(job *Job) error
+	// Take removes a pending job and returns it.
+	Take(id string) (*Job, bool)
+	// TakeExpired removes the pending jobs whose deadline is before now and returns them.
+	TakeExpired(now time.Time) []*Job
+}
+
+// MemoryJobStore is a JobStore which keeps jobs in memory.
+type MemoryJobStore struct {
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+// NewMemoryJobStore creates an empty MemoryJobStore.
+func NewMemoryJobStore() *MemoryJobStore {
+	return &MemoryJobStore{jobs: make(map[string]*Job)}
+}
+
+func (s *MemoryJobStore) Add(job *Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs[job.ID()] = job
+
+	return nil
+}
+
+func (s *MemoryJobStore) Take(id string) (*Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	delete(s.jobs, id)
+
+	return job, ok
+}
+
+func (s *MemoryJobStore) TakeExpired(now time.Time) []*Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var expired []*Job
+	for id, job := range s.jobs {
+		if job.Deadline().Before(now) {
+			expired = append(expired, job)
+			delete(s.jobs, id)
+		}
+	}
+
+	return expired
+}
+
+// Jobs pairs the requests submitted with Client.Submit with the webhook calls made by Gotenberg.
+// It is an http.Handler which must be served at the webhook URL.
+type Jobs struct {
+	hookURL  string
+	errorURL string
+	store    JobStore
+	timeout  time.Duration
+	handler  *WebhookHandler
+}
+
+// NewJobs creates Jobs whose webhook is served at hookURL, with jobs kept in memory for up to 10 minutes.
+func NewJobs(hookURL string) (*Jobs, error) {
+	u, err := url.Parse(hookURL)
+	if err != nil || !u.IsAbs() {
+		return nil, fmt.Errorf("webhook URL %s must be absolute", hookURL)
+	}
+
+	errorURL, err := WebhookErrorURL(hookURL)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := &Jobs{
+		hookURL:  hookURL,
+		errorURL: errorURL,
+		store:    NewMemoryJobStore(),
+		timeout:  defaultJobTimeout,
+	}
+	jobs.handler = NewWebhookHandler(jobs.onSuccess, jobs.onError)
+
+	return jobs, nil
+}
+
+// Store sets where pending jobs are held.
+func (js *Jobs) Store(store JobStore) {
+	js.store = store
+}
+
+// Timeout sets how long a job waits for its webhook call.
+func (js *Jobs) Timeout(timeout time.Duration) {
+	js.timeout = timeout
+}
+
+// BodyLimit sets the maximum size of a resulting file, in bytes. Results are held in memory until Wait returns them.
+func (js *Jobs) BodyLimit(bytes int64) {
+	js.handler.BodyLimit(bytes)
+}
+
+// VerifySignature makes Jobs reject the calls whose URL was not signed with key, see WebhookHandler.VerifySignature.
+func (js *Jobs) VerifySignature(key []byte) {
+	js.handler.VerifySignature(key)
+}
+
+func (js *Jobs) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	js.expire()
+	js.handler.ServeHTTP(w, r)
+}
+
+// expire times out the jobs whose deadline has passed, even if nobody waits for them.
+func (js *Jobs) expire() {
+	for _, job := range js.store.TakeExpired(time.Now()) {
+		job.complete(nil, ErrJobTimeout)
+	}
+}
+
+func (js *Jobs) onSuccess(_ context.Context, res WebhookResult) error {
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("reading result: %w", err)
+	}
+
+	// Calls for unknown or expired jobs are acknowledged, so that Gotenberg does not retry them.
+	if job, ok := js.store.Take(res.Header.Get(jobIDHeader)); ok {
+		job.complete(&JobResult{
+			Filename:    res.Filename,
+			ContentType: res.ContentType,
+			Trace:       res.Trace,
+			Header:      res.Header,
+			Data:        data,
+		}, nil)
+	}
+
+	return nil
+}
+
+func (js *Jobs) onError(_ context.Context, werr WebhookError) error {
+	if job, ok := js.store.Take(werr.Header.Get(jobIDHeader)); ok {
+		job.complete(nil, werr)
+	}
+
+	return nil
+}
+
+// WithJobs makes the client able to Submit requests, whose results are received by jobs.
+func WithJobs(jobs *Jobs) ClientOption {
+	return func(c *Client) {
+		c.jobs = jobs
+	}
+}
+
+// Submit sends a request whose result is delivered to the webhook of the client's Jobs, and returns
+// the Job to wait for it. The request is sent with the webhook URLs of the Jobs, and a correlation ID
+// added to its webhook extra headers. The request itself is not modified, so it can be submitted again.
+func (c *Client) Submit(ctx context.Context, req multipartRequester) (*Job, error) {
+	if c.jobs == nil {
+		return nil, errNoJobs
+	}
+
+	c.jobs.expire()
+
+	id, err := newCorrelationID()
+	if err != nil {
+		return nil, err
+	}
+
+	headers := maps.Clone(req.customHeaders())
+	headers[headerWebhookURL] = c.jobs.hookURL
+	headers[headerWebhookErrorURL] = c.jobs.errorURL
+
+	if err = addWebhookExtraHeader(headers, jobIDHeader, id); err != nil {
+		return nil, err
+	}
+
+	job := newJob(id, time.Now().Add(c.jobs.timeout))
+	job.expire = func() {
+		c.jobs.store.Take(id)
+	}
+
+	if err = c.jobs.store.Add(job); err != nil {
+		return nil, fmt.Errorf("storing job: %w", err)
+	}
+
+	resp, err := c.send(ctx, &requestWithHeaders{multipartRequester: req, headers: headers})
+	if err != nil {
+		c.jobs.store.Take(id)
+
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		c.jobs.store.Take(id)
+
+		return nil, fmt.Errorf("%w: %d", errGenerationFailed, resp.StatusCode)
+	}
+
+	job.trace = resp.Header.Get(string(headerTrace))
+
+	return job, nil
+}
+
+// requestWithHeaders sends a request with other headers, leaving the request unchanged.
+type requestWithHeaders struct {
+	multipartRequester
+
+	headers map[httpHeader]string
+}
+
+func (req *requestWithHeaders) customHeaders() map[httpHeader]string {
+	return req.headers
+}
+
+// addWebhookExtraHeader adds a header to those already set with SetWebhookExtraHeaders.
+func addWebhookExtraHeader(headers map[httpHeader]string, key, value string) error {
+	extra := make(map[string]string)
+	if current := headers[headerWebhookExtraHeaders]; current != "" {
+		if err := json.Unmarshal([]byte(current), &extra); err != nil {
+			return fmt.Errorf("unmarshal webhook extra headers: %w", err)
+		}
+	}
+
+	extra[key] = value
+
+	marshaled, err := json.Marshal(extra)
+	if err != nil {
+		return fmt.Errorf("marshal headers to JSON: %w", err)
+	}
+
+	headers[headerWebhookExtraHeaders] = string(marshaled)
+
+	return nil
+}
+
+func newCorrelationID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating job ID: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = http.Handler(new(Jobs))
+	_ = JobStore(new(MemoryJobStore))
+)
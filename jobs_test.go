@@ -0,0 +1,156 @@
+package gotenberg
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+// newWebhookServer fakes Gotenberg: it replies at once, then calls the webhook with result, or
+// the error webhook if fail is set. Nothing is called if result is empty.
+func newWebhookServer(t *testing.T, result string, fail bool) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+
+		var extra map[string]string
+		_ = json.Unmarshal([]byte(r.Header.Get("Gotenberg-Webhook-Extra-Http-Headers")), &extra)
+
+		hookURL, body := r.Header.Get("Gotenberg-Webhook-Url"), result
+		if fail {
+			hookURL, body = r.Header.Get("Gotenberg-Webhook-Error-Url"), `{"status":400,"message":"bad"}`
+		}
+
+		w.Header().Set("Gotenberg-Trace", "trace")
+		w.WriteHeader(http.StatusNoContent)
+
+		if result == "" {
+			return
+		}
+
+		go func() {
+			req, _ := http.NewRequest(http.MethodPost, hookURL, strings.NewReader(body))
+			req.Header.Set("Content-Disposition", `attachment; filename="out.pdf"`)
+			for key, value := range extra {
+				req.Header.Set(key, value)
+			}
+
+			if resp, err := http.DefaultClient.Do(req); err == nil {
+				_ = resp.Body.Close()
+			}
+		}()
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func newJobsClient(t *testing.T, gotenbergURL string) (*Client, *Jobs) {
+	t.Helper()
+
+	hook := httptest.NewUnstartedServer(nil)
+	jobs, err := NewJobs("http://" + hook.Listener.Addr().String() + "/hook")
+	require.NoError(t, err)
+	hook.Config.Handler = jobs
+	hook.Start()
+	t.Cleanup(hook.Close)
+
+	c, err := NewClient(gotenbergURL, nil, WithJobs(jobs))
+	require.NoError(t, err)
+
+	return c, jobs
+}
+
+func newJobRequest(t *testing.T) *LibreOfficeRequest {
+	t.Helper()
+
+	doc, err := document.FromString("report.txt", "report")
+	require.NoError(t, err)
+
+	req := NewLibreOfficeRequest(doc)
+	require.NoError(t, req.SetWebhookExtraHeaders(map[string]string{"X-Tenant": "acme"}))
+
+	return req
+}
+
+func TestSubmit(t *testing.T) {
+	c, _ := newJobsClient(t, newWebhookServer(t, "%PDF-result", false).URL)
+
+	job, err := c.Submit(context.Background(), newJobRequest(t))
+	require.NoError(t, err)
+	assert.Len(t, job.ID(), 32)
+	assert.Equal(t, "trace", job.Trace())
+
+	res, err := job.Wait(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-result", string(res.Data))
+	assert.Equal(t, "out.pdf", res.Filename)
+	assert.Equal(t, "acme", res.Header.Get("X-Tenant"))
+}
+
+func TestSubmitTwice(t *testing.T) {
+	c, _ := newJobsClient(t, newWebhookServer(t, "%PDF-result", false).URL)
+	req := newJobRequest(t)
+
+	first, err := c.Submit(context.Background(), req)
+	require.NoError(t, err)
+	second, err := c.Submit(context.Background(), req)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID(), second.ID())
+
+	for _, job := range []*Job{first, second} {
+		res, err := job.Wait(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, job.ID(), res.Header.Get(jobIDHeader))
+	}
+
+	// The job's webhook and correlation ID are not left on the request.
+	assert.NotContains(t, req.customHeaders(), headerWebhookURL)
+	assert.JSONEq(t, `{"X-Tenant":"acme"}`, req.customHeaders()[headerWebhookExtraHeaders])
+}
+
+func TestSubmitError(t *testing.T) {
+	c, _ := newJobsClient(t, newWebhookServer(t, "%PDF-result", true).URL)
+
+	job, err := c.Submit(context.Background(), newJobRequest(t))
+	require.NoError(t, err)
+
+	_, err = job.Wait(context.Background())
+
+	var werr WebhookError
+	require.ErrorAs(t, err, &werr)
+	assert.Equal(t, 400, werr.Status)
+}
+
+func TestSubmitTimeout(t *testing.T) {
+	c, jobs := newJobsClient(t, newWebhookServer(t, "", false).URL)
+	jobs.Timeout(50 * time.Millisecond)
+
+	job, err := c.Submit(context.Background(), newJobRequest(t))
+	require.NoError(t, err)
+
+	_, err = job.Wait(context.Background())
+	require.ErrorIs(t, err, ErrJobTimeout)
+
+	_, ok := jobs.store.Take(job.ID())
+	assert.False(t, ok)
+}
+
+func TestSubmitWithoutJobs(t *testing.T) {
+	c, err := NewClient("http://localhost", nil)
+	require.NoError(t, err)
+
+	_, err = c.Submit(context.Background(), newJobRequest(t))
+	require.ErrorIs(t, err, errNoJobs)
+}
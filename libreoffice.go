@@ -50,6 +50,12 @@ func (req *LibreOfficeRequest) NativePageRanges(ranges string) {
 	req.fields[fieldOfficeNativePageRanges] = ranges
 }
 
+// NativePageRangesOf sets the page ranges to print, e.g., NativePageRangesOf(Range(1, 4)).
+// No ranges means all pages. Valid ranges are normalised, invalid ones are reported by Validate.
+func (req *LibreOfficeRequest) NativePageRangesOf(ranges ...PageRange) {
+	req.fields[fieldOfficeNativePageRanges] = pageRangesField(ranges)
+}
+
 // ExportFormFields specifies whether form fields are exported as widgets
 // or only their fixed print representation is exported.
 func (req *LibreOfficeRequest) ExportFormFields(export bool) {
@@ -177,6 +183,37 @@ func (req *LibreOfficeRequest) Merge() {
 	req.fields[fieldOfficeMerge] = strconv.FormatBool(true)
 }
 
+// Validate checks the request options before it is sent.
+func (req *LibreOfficeRequest) Validate() error {
+	v := &validator{}
+	v.documents("files", req.docs, req.requiredDocuments(1))
+	v.validateBase(req.baseRequest)
+
+	if raw, ok := req.fields[fieldOfficeQuality]; ok {
+		if quality, err := strconv.Atoi(raw); err != nil || quality < minOfficeQuality || quality > maxOfficeQuality {
+			v.addf(string(fieldOfficeQuality), "must be between %d and %d, got %s", minOfficeQuality, maxOfficeQuality, raw)
+		}
+	}
+
+	if raw, ok := req.fields[fieldOfficeMaxImageResolution]; ok {
+		switch raw {
+		case "75", "150", "300", "600", "1200":
+		default:
+			v.addf(string(fieldOfficeMaxImageResolution), "must be one of 75, 150, 300, 600 or 1200, got %s", raw)
+		}
+	}
+
+	if raw, ok := req.fields[fieldOfficeNativePageRanges]; ok {
+		v.pageRanges(string(fieldOfficeNativePageRanges), raw)
+	}
+
+	if raw, ok := req.fields[fieldOfficePdfA]; ok {
+		v.pdfA(string(fieldOfficePdfA), raw)
+	}
+
+	return v.err()
+}
+
 // Compile-time checks to ensure type implements desired interfaces.
 var (
 	_ = multipartRequester(new(LibreOfficeRequest))
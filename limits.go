@@ -0,0 +1,152 @@
+package gotenberg
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"sync"
+)
+
+// SizeLimitError reports a document which exceeded a size limit while the request body was built.
+type SizeLimitError struct {
+	// Filename is the document being uploaded when the limit was exceeded.
+	Filename string
+	// Limit is the exceeded limit in bytes.
+	Limit int64
+	// PerRequest is true if the limit on all documents of the request was exceeded, rather
+	// than the limit on a single document.
+	PerRequest bool
+}
+
+func (e *SizeLimitError) Error() string {
+	if e.PerRequest {
+		return fmt.Sprintf("%s: request exceeds the size limit of %d bytes", e.Filename, e.Limit)
+	}
+
+	return fmt.Sprintf("%s: document exceeds the size limit of %d bytes", e.Filename, e.Limit)
+}
+
+// WithDocumentSizeLimit limits the size of each document of a request. Documents are measured while
+// they are uploaded, and the request fails with a *SizeLimitError as soon as one exceeds the limit.
+func WithDocumentSizeLimit(bytes int64) ClientOption {
+	return func(c *Client) {
+		c.documentSizeLimit = bytes
+	}
+}
+
+// WithRequestSizeLimit limits the total size of the documents of a request. Documents are measured while
+// they are uploaded, and the request fails with a *SizeLimitError as soon as the total exceeds the limit.
+func WithRequestSizeLimit(bytes int64) ClientOption {
+	return func(c *Client) {
+		c.requestSizeLimit = bytes
+	}
+}
+
+// UploadStats describes what was uploaded by the last successful send of a request. It is empty if
+// the result came from the cache, or from the call of an identical request, see WithDeduplication.
+type UploadStats struct {
+	// Total is the size of the whole request body, including form fields and multipart framing.
+	Total int64
+	// Documents maps each uploaded filename to its size.
+	Documents map[string]int64
+}
+
+// uploadRecorder stores the upload stats of a request.
+type uploadRecorder struct {
+	mu    sync.Mutex
+	stats UploadStats
+}
+
+func (ur *uploadRecorder) get() UploadStats {
+	ur.mu.Lock()
+	defer ur.mu.Unlock()
+
+	return UploadStats{Total: ur.stats.Total, Documents: maps.Clone(ur.stats.Documents)}
+}
+
+func (ur *uploadRecorder) set(stats UploadStats) {
+	ur.mu.Lock()
+	defer ur.mu.Unlock()
+
+	ur.stats = stats
+}
+
+// uploadCounter counts the bytes written to the request body and read from documents, and enforces limits.
+type uploadCounter struct {
+	documentLimit int64
+	requestLimit  int64
+
+	progress *progressReporter
+
+	mu        sync.Mutex
+	total     int64
+	documents int64
+	sizes     map[string]int64
+	current   string
+}
+
+func (uc *uploadCounter) stats() UploadStats {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	return UploadStats{Total: uc.total, Documents: maps.Clone(uc.sizes)}
+}
+
+// body wraps the request body writer to count every byte of it.
+func (uc *uploadCounter) body(w io.Writer) io.Writer {
+	return writerFunc(func(p []byte) (int, error) {
+		n, err := w.Write(p)
+
+		uc.mu.Lock()
+		uc.total += int64(n)
+		current, currentBytes, total := uc.current, uc.sizes[uc.current], uc.total
+		uc.mu.Unlock()
+
+		uc.progress.uploaded(current, currentBytes, total)
+
+		return n, err
+	})
+}
+
+// document wraps the reader of a document to count its bytes and enforce the limits.
+func (uc *uploadCounter) document(fname string, r io.Reader) io.Reader {
+	uc.mu.Lock()
+	if uc.sizes == nil {
+		uc.sizes = make(map[string]int64)
+	}
+	uc.sizes[fname] = 0
+	uc.current = fname
+	uc.mu.Unlock()
+
+	return readerFunc(func(p []byte) (int, error) {
+		n, err := r.Read(p)
+
+		uc.mu.Lock()
+		defer uc.mu.Unlock()
+
+		uc.sizes[fname] += int64(n)
+		uc.documents += int64(n)
+
+		if uc.documentLimit > 0 && uc.sizes[fname] > uc.documentLimit {
+			return n, &SizeLimitError{Filename: fname, Limit: uc.documentLimit}
+		}
+
+		if uc.requestLimit > 0 && uc.documents > uc.requestLimit {
+			return n, &SizeLimitError{Filename: fname, Limit: uc.requestLimit, PerRequest: true}
+		}
+
+		return n, err
+	})
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
+
+type readerFunc func(p []byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) {
+	return f(p)
+}
@@ -0,0 +1,89 @@
+package gotenberg
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func newDiscardServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestDocumentSizeLimit(t *testing.T) {
+	srv := newDiscardServer(t)
+
+	small, err := document.FromString("small.txt", "small")
+	require.NoError(t, err)
+	big, err := document.FromString("big.txt", strings.Repeat("x", 100))
+	require.NoError(t, err)
+
+	c, err := NewClient(srv.URL, nil, WithDocumentSizeLimit(50))
+	require.NoError(t, err)
+
+	_, err = c.Send(context.Background(), NewLibreOfficeRequest(small, big))
+
+	var limitErr *SizeLimitError
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, "big.txt", limitErr.Filename)
+	assert.Equal(t, int64(50), limitErr.Limit)
+	assert.False(t, limitErr.PerRequest)
+}
+
+func TestRequestSizeLimit(t *testing.T) {
+	srv := newDiscardServer(t)
+
+	first, err := document.FromString("first.txt", strings.Repeat("x", 40))
+	require.NoError(t, err)
+	second, err := document.FromString("second.txt", strings.Repeat("x", 40))
+	require.NoError(t, err)
+
+	c, err := NewClient(srv.URL, nil, WithDocumentSizeLimit(50), WithRequestSizeLimit(60))
+	require.NoError(t, err)
+
+	_, err = c.Send(context.Background(), NewLibreOfficeRequest(first, second))
+
+	var limitErr *SizeLimitError
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, int64(60), limitErr.Limit)
+	assert.True(t, limitErr.PerRequest)
+}
+
+func TestUploadStats(t *testing.T) {
+	srv := newDiscardServer(t)
+
+	first, err := document.FromString("first.txt", "first")
+	require.NoError(t, err)
+	second, err := document.FromString("second.txt", "second document")
+	require.NoError(t, err)
+
+	c, err := NewClient(srv.URL, nil, WithRequestSizeLimit(1024))
+	require.NoError(t, err)
+
+	req := NewLibreOfficeRequest(first, second)
+	assert.Zero(t, req.UploadStats().Total)
+
+	resp, err := c.Send(context.Background(), req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	stats := req.UploadStats()
+	assert.Equal(t, map[string]int64{"first.txt": 5, "second.txt": 15}, stats.Documents)
+	assert.Greater(t, stats.Total, int64(20))
+}
@@ -0,0 +1,258 @@
+package gotenberg
+
+import (
+	"fmt"
+	"html"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+// DefaultMarkdownStylesheet is the stylesheet of the index generated by MarkdownIndex.
+const DefaultMarkdownStylesheet = `body {
+  font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif;
+  font-size: 11pt;
+  line-height: 1.5;
+  color: #1f2328;
+}
+h1, h2, h3, h4 { line-height: 1.25; margin: 1.2em 0 0.5em; }
+h1 { font-size: 2em; border-bottom: 1px solid #d1d9e0; padding-bottom: 0.3em; }
+h2 { font-size: 1.5em; border-bottom: 1px solid #d1d9e0; padding-bottom: 0.3em; }
+a { color: #0969da; text-decoration: none; }
+img { max-width: 100%; }
+blockquote { margin: 0; padding: 0 1em; color: #59636e; border-left: 0.25em solid #d1d9e0; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #d1d9e0; padding: 6px 13px; }
+tr:nth-child(2n) { background: #f6f8fa; }
+section.markdown + section.markdown { break-before: page; }
+nav#toc ul { list-style: none; padding-left: 0; }
+nav#toc li.toc-h2 { padding-left: 1.5em; }
+nav#toc li.toc-h3 { padding-left: 3em; }
+nav#toc { break-after: page; }
+`
+
+// DefaultCodeStylesheet is the code stylesheet of the index generated by MarkdownIndex. Gotenberg does
+// not tokenize code blocks, so it only styles them as a whole; see MarkdownIndex.Script to highlight syntax.
+const DefaultCodeStylesheet = `code, pre {
+  font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace;
+  font-size: 0.9em;
+}
+code { background: #eff1f3; border-radius: 4px; padding: 0.2em 0.4em; }
+pre { background: #f6f8fa; border-radius: 6px; padding: 1em; overflow-x: auto; white-space: pre-wrap; }
+pre code { background: none; padding: 0; }
+`
+
+// tocScript fills nav#toc with links to the h1, h2 and h3 headings of the markdown sections.
+const tocScript = `(function () {
+  var toc = document.getElementById("toc");
+  var list = document.createElement("ul");
+  var headings = document.querySelectorAll("section.markdown h1, section.markdown h2, section.markdown h3");
+  headings.forEach(function (heading, i) {
+    if (!heading.id) {
+      heading.id = "section-" + (i + 1);
+    }
+    var link = document.createElement("a");
+    link.href = "#" + heading.id;
+    link.textContent = heading.textContent;
+    var item = document.createElement("li");
+    item.className = "toc-" + heading.tagName.toLowerCase();
+    item.appendChild(link);
+    list.appendChild(item);
+  });
+  toc.appendChild(list);
+})();
+`
+
+// nolint: gochecknoglobals
+var (
+	toHTMLRe        = regexp.MustCompile(`\{\{-?\s*toHTML\s+"([^"]+)"\s*-?\}\}`)
+	closingScriptRe = regexp.MustCompile(`(?i)</(style|script)`)
+)
+
+// MarkdownFile is a markdown document given as a string.
+type MarkdownFile struct {
+	// Name is the filename, with the .md extension.
+	Name    string
+	Content string
+}
+
+// MarkdownIndex builds the index.html of a markdown request, which renders each markdown file in turn
+// with Gotenberg's toHTML template function.
+type MarkdownIndex struct {
+	title          string
+	lang           string
+	stylesheet     string
+	codeStylesheet string
+	scripts        []string
+	toc            bool
+}
+
+// NewMarkdownIndex creates an index with DefaultMarkdownStylesheet and DefaultCodeStylesheet.
+func NewMarkdownIndex() *MarkdownIndex {
+	return &MarkdownIndex{
+		lang:           "en",
+		stylesheet:     DefaultMarkdownStylesheet,
+		codeStylesheet: DefaultCodeStylesheet,
+	}
+}
+
+// Title sets the title of the document, which headers and footers show with PlaceholderTitle.
+func (mi *MarkdownIndex) Title(title string) {
+	mi.title = title
+}
+
+// Lang sets the language of the document, "en" by default.
+func (mi *MarkdownIndex) Lang(lang string) {
+	mi.lang = lang
+}
+
+// Stylesheet replaces the stylesheet of the document.
+func (mi *MarkdownIndex) Stylesheet(css string) {
+	mi.stylesheet = css
+}
+
+// CodeStylesheet replaces the stylesheet of code blocks, e.g. with the theme of a syntax highlighter.
+func (mi *MarkdownIndex) CodeStylesheet(css string) {
+	mi.codeStylesheet = css
+}
+
+// Script adds JavaScript run once the markdown is rendered and before the page is printed, e.g. a syntax
+// highlighter such as highlight.js followed by its hljs.highlightAll() call.
+func (mi *MarkdownIndex) Script(js string) {
+	mi.scripts = append(mi.scripts, js)
+}
+
+// TableOfContents adds a table of contents, on its own page, linking to the h1, h2 and h3 headings.
+func (mi *MarkdownIndex) TableOfContents() {
+	mi.toc = true
+}
+
+// HTML returns the index rendering the markdown files, in order.
+func (mi *MarkdownIndex) HTML(names ...string) (string, error) {
+	for _, code := range append([]string{mi.stylesheet, mi.codeStylesheet}, mi.scripts...) {
+		if closingScriptRe.MatchString(code) {
+			return "", fmt.Errorf("stylesheets and scripts must not contain %q", closingScriptRe.FindString(code))
+		}
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "<!doctype html>\n<html lang=\"%s\">\n<head>\n", html.EscapeString(mi.lang))
+	b.WriteString("<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(mi.title))
+
+	for _, css := range []string{mi.stylesheet, mi.codeStylesheet} {
+		if css != "" {
+			fmt.Fprintf(&b, "<style>\n%s</style>\n", css)
+		}
+	}
+
+	b.WriteString("</head>\n<body>\n")
+
+	if mi.toc {
+		b.WriteString("<nav id=\"toc\"></nav>\n")
+	}
+
+	for _, name := range names {
+		if !validMarkdownName(name) {
+			return "", fmt.Errorf("invalid markdown filename %q", name)
+		}
+
+		fmt.Fprintf(&b, "<section class=\"markdown\">\n{{ toHTML %q }}\n</section>\n", name)
+	}
+
+	scripts := mi.scripts
+	if mi.toc {
+		scripts = append([]string{tocScript}, scripts...)
+	}
+
+	for _, js := range scripts {
+		fmt.Fprintf(&b, "<script>\n%s</script>\n", js)
+	}
+
+	b.WriteString("</body>\n</html>\n")
+
+	return b.String(), nil
+}
+
+// validMarkdownName accepts the flat .md filenames which can be quoted in a toHTML call.
+func validMarkdownName(name string) bool {
+	return strings.EqualFold(path.Ext(name), ".md") && path.Base(name) == name &&
+		!strings.ContainsAny(name, "\"\\{}")
+}
+
+// NewMarkdownRequestFromStrings creates a markdown request from markdown content, rendered in order by
+// the index generated by index, or by NewMarkdownIndex if index is nil.
+func NewMarkdownRequestFromStrings(index *MarkdownIndex, files ...MarkdownFile) (*MarkdownRequest, error) {
+	if index == nil {
+		index = NewMarkdownIndex()
+	}
+
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		names = append(names, f.Name)
+	}
+
+	content, err := index.HTML(names...)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMarkdownRequestWithIndex(content, files...)
+}
+
+// NewMarkdownRequestWithIndex creates a markdown request from markdown content and an index using
+// Gotenberg's toHTML template function, e.g. {{ toHTML "intro.md" }}. It fails if a markdown file is
+// not referenced by the index, or if the index references a missing one.
+func NewMarkdownRequestWithIndex(index string, files ...MarkdownFile) (*MarkdownRequest, error) {
+	v := &validator{}
+
+	referenced := make(map[string]bool)
+	for _, m := range toHTMLRe.FindAllStringSubmatch(index, -1) {
+		referenced[m[1]] = true
+	}
+
+	given := make(map[string]bool, len(files))
+	docs := make([]document.Document, 0, len(files))
+
+	for _, f := range files {
+		switch {
+		case !validMarkdownName(f.Name):
+			v.addf("markdowns", "invalid filename %q, expected a flat .md filename", f.Name)
+		case given[f.Name]:
+			v.addf("markdowns", "duplicate file %s", f.Name)
+		case !referenced[f.Name]:
+			v.addf("markdowns", "file %s is not referenced by the index", f.Name)
+		}
+
+		given[f.Name] = true
+
+		doc, err := document.FromString(f.Name, f.Content)
+		if err != nil {
+			v.addf("markdowns", "%v", err)
+
+			continue
+		}
+
+		docs = append(docs, doc)
+	}
+
+	for _, name := range sortedKeys(referenced) {
+		if !given[name] {
+			v.addf("index.html", "references missing file %s", name)
+		}
+	}
+
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	indexDoc, err := document.FromString("index.html", index)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMarkdownRequest(indexDoc, docs...), nil
+}
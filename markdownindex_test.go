@@ -0,0 +1,85 @@
+package gotenberg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func markdownDocument(t *testing.T, req *MarkdownRequest, fname string) string {
+	t.Helper()
+
+	doc, ok := req.formDocuments()[fname]
+	require.True(t, ok, "missing %s", fname)
+
+	return readDocument(t, doc)
+}
+
+func TestNewMarkdownRequestFromStrings(t *testing.T) {
+	req, err := NewMarkdownRequestFromStrings(nil,
+		MarkdownFile{Name: "intro.md", Content: "# Intro"},
+		MarkdownFile{Name: "usage.md", Content: "# Usage\n\n```go\nfmt.Println()\n```"},
+	)
+	require.NoError(t, err)
+	require.NoError(t, req.Validate())
+
+	index := markdownDocument(t, req, "index.html")
+	assert.Contains(t, index, `{{ toHTML "intro.md" }}`)
+	assert.Contains(t, index, `{{ toHTML "usage.md" }}`)
+	assert.Less(t, strings.Index(index, "intro.md"), strings.Index(index, "usage.md"))
+	assert.Contains(t, index, DefaultMarkdownStylesheet)
+	assert.Contains(t, index, DefaultCodeStylesheet)
+	assert.NotContains(t, index, `<nav id="toc">`)
+
+	assert.Equal(t, "# Intro", markdownDocument(t, req, "intro.md"))
+}
+
+func TestMarkdownIndexOptions(t *testing.T) {
+	mi := NewMarkdownIndex()
+	mi.Title(`Guide <v2>`)
+	mi.Stylesheet("body { color: red; }\n")
+	mi.CodeStylesheet(".hljs-keyword { color: blue; }\n")
+	mi.Script("hljs.highlightAll();\n")
+	mi.TableOfContents()
+
+	index, err := mi.HTML("guide.md")
+	require.NoError(t, err)
+	assert.Contains(t, index, "<title>Guide &lt;v2&gt;</title>")
+	assert.Contains(t, index, "body { color: red; }")
+	assert.Contains(t, index, ".hljs-keyword { color: blue; }")
+	assert.NotContains(t, index, DefaultMarkdownStylesheet)
+	assert.Contains(t, index, `<nav id="toc"></nav>`)
+	assert.Less(t, strings.Index(index, `getElementById("toc")`), strings.Index(index, "hljs.highlightAll()"),
+		"the table of contents is built before other scripts run")
+
+	mi.Script("</script><script>alert(1)")
+	_, err = mi.HTML("guide.md")
+	require.Error(t, err)
+
+	_, err = NewMarkdownIndex().HTML(`bad".md`)
+	require.Error(t, err)
+}
+
+func TestNewMarkdownRequestWithIndex(t *testing.T) {
+	index := `<html><body>{{ toHTML "a.md" }} {{toHTML "missing.md"}}</body></html>`
+
+	_, err := NewMarkdownRequestWithIndex(index,
+		MarkdownFile{Name: "a.md", Content: "# A"},
+		MarkdownFile{Name: "b.md", Content: "# B"},
+		MarkdownFile{Name: "a.md", Content: "# A again"},
+		MarkdownFile{Name: "c.txt", Content: "C"},
+	)
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.ErrorContains(t, err, "file b.md is not referenced by the index")
+	assert.ErrorContains(t, err, "duplicate file a.md")
+	assert.ErrorContains(t, err, `invalid filename "c.txt"`)
+	assert.ErrorContains(t, err, "references missing file missing.md")
+
+	req, err := NewMarkdownRequestWithIndex(`{{ toHTML "a.md" }}`, MarkdownFile{Name: "a.md", Content: "# A"})
+	require.NoError(t, err)
+	assert.Equal(t, `{{ toHTML "a.md" }}`, markdownDocument(t, req, "index.html"))
+}
@@ -31,6 +31,15 @@ func (rmd *ReadMetadataRequest) formDocuments() map[string]document.Document {
 	return files
 }
 
+// Validate checks the request options before it is sent.
+func (rmd *ReadMetadataRequest) Validate() error {
+	v := &validator{}
+	v.documents("files", rmd.pdfs, rmd.requiredDocuments(1), ".pdf")
+	v.validateBase(rmd.baseRequest)
+
+	return v.err()
+}
+
 // Compile-time checks to ensure type implements desired interfaces.
 var (
 	_ = multipartRequester(new(ReadMetadataRequest))
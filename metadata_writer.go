@@ -35,6 +35,19 @@ func (wmd *WriteMetadataRequest) Metadata(md []byte) {
 	wmd.fields[fieldMetadata] = string(md)
 }
 
+// Validate checks the request options before it is sent.
+func (wmd *WriteMetadataRequest) Validate() error {
+	v := &validator{}
+	v.documents("files", wmd.pdfs, wmd.requiredDocuments(1), ".pdf")
+	v.validateBase(wmd.baseRequest)
+
+	if _, ok := wmd.fields[fieldMetadata]; !ok {
+		v.addf(string(fieldMetadata), "metadata is required")
+	}
+
+	return v.err()
+}
+
 // Compile-time checks to ensure type implements desired interfaces.
 var (
 	_ = multipartRequester(new(WriteMetadataRequest))
@@ -1,38 +1,131 @@
 package gotenberg
 
 import (
-	"bytes"
+	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/textproto"
+	"strings"
 
 	"github.com/starwalkn/gotenberg-go-client/v8/document"
 )
 
-func multipartForm(mr multipartRequester) (body *bytes.Buffer, contentType string, err error) {
-	body = &bytes.Buffer{}
+// nolint: gochecknoglobals
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
 
-	writer := multipart.NewWriter(body)
-	defer func() {
-		if closeErr := writer.Close(); closeErr != nil {
-			err = fmt.Errorf("error closing writer: %w", closeErr)
+// formWriter writes the multipart form of a request according to the client settings.
+type formWriter struct {
+	writer  *multipart.Writer
+	counter *uploadCounter
+
+	checkContent bool
+	validate     bool
+}
+
+// multipartForm streams the form fields and documents of the request. Documents are read only
+// while the returned body is consumed, so they are never fully held in memory.
+func (c *Client) multipartForm(mr multipartRequester) (io.ReadCloser, *formWriter) {
+	pr, pw := io.Pipe()
+	counter := &uploadCounter{
+		documentLimit: c.documentSizeLimit,
+		requestLimit:  c.requestSizeLimit,
+		progress:      newProgressReporter(c.progress, mr.progressFunc()),
+	}
+	fw := &formWriter{
+		writer:       multipart.NewWriter(counter.body(pw)),
+		counter:      counter,
+		checkContent: !c.skipContentCheck,
+		validate:     !c.skipValidation,
+	}
+
+	go func() {
+		err := fw.write(mr)
+		if err == nil {
+			if closeErr := fw.writer.Close(); closeErr != nil {
+				err = fmt.Errorf("error closing writer: %w", closeErr)
+			}
 		}
+
+		if err == nil {
+			counter.progress.waiting()
+		}
+
+		// A nil error makes the reader side receive io.EOF.
+		pw.CloseWithError(err)
 	}()
 
-	if err = addDocuments(writer, mr.formDocuments()); err != nil {
-		return nil, "", err
+	return pr, fw
+}
+
+func (fw *formWriter) write(mr multipartRequester) error {
+	docs := mr.formDocuments()
+
+	opened, err := fw.resolveFilenames(mr, docs)
+	if err != nil {
+		return err
 	}
 
-	if err = addFormFields(writer, mr.formFields()); err != nil {
-		return nil, "", err
+	if err = fw.addDocuments(docs, opened); err != nil {
+		return err
 	}
 
-	return body, writer.FormDataContentType(), nil
+	return fw.addFormFields(mr.formFields())
+}
+
+// openedDocument is a document opened before the form is written, to learn its final filename.
+type openedDocument struct {
+	in io.ReadCloser
+	// ownFilename is true if the document was sent under its own filename before it was opened.
+	ownFilename bool
 }
 
-func addFormFields(writer *multipart.Writer, formFields map[formField]string) error {
+// resolveFilenames opens the documents whose filename is only known once opened, e.g. remote ones,
+// and validates the request again with their final filenames, which validation skipped so far.
+func (fw *formWriter) resolveFilenames(
+	mr multipartRequester,
+	docs map[string]document.Document,
+) (map[string]openedDocument, error) {
+	opened := make(map[string]openedDocument)
+
+	for fname, doc := range docs {
+		if doc == nil || document.FilenameResolved(doc) {
+			continue
+		}
+
+		ownFilename := fname == doc.Filename()
+
+		in, err := doc.Reader()
+		if err != nil {
+			closeDocuments(opened)
+
+			return nil, fmt.Errorf("getting %s reader: %w", fname, err)
+		}
+
+		opened[fname] = openedDocument{in: in, ownFilename: ownFilename}
+	}
+
+	if len(opened) > 0 && fw.validate {
+		if err := mr.Validate(); err != nil {
+			closeDocuments(opened)
+
+			return nil, err
+		}
+	}
+
+	return opened, nil
+}
+
+func closeDocuments(opened map[string]openedDocument) {
+	for _, doc := range opened {
+		_ = doc.in.Close()
+	}
+}
+
+func (fw *formWriter) addFormFields(formFields map[formField]string) error {
 	for name, value := range formFields {
-		if err := writer.WriteField(string(name), value); err != nil {
+		if err := fw.writer.WriteField(string(name), value); err != nil {
 			return fmt.Errorf("writing %s form field: %w", name, err)
 		}
 	}
@@ -40,26 +133,73 @@ func addFormFields(writer *multipart.Writer, formFields map[formField]string) er
 	return nil
 }
 
-func addDocuments(writer *multipart.Writer, documents map[string]document.Document) error {
+func (fw *formWriter) addDocuments(documents map[string]document.Document, opened map[string]openedDocument) error {
+	// The documents opened beforehand are closed once written, or if the form cannot be written.
+	defer closeDocuments(opened)
+
 	for fname, doc := range documents {
-		in, err := doc.Reader()
-		if err != nil {
+		if err := fw.addDocument(fname, doc, opened); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (fw *formWriter) addDocument(fname string, doc document.Document, opened map[string]openedDocument) error {
+	// Some documents, e.g. remote ones, only know their final filename once opened.
+	// It is used unless the request imposes its own filename, like index.html.
+	ownFilename := fname == doc.Filename()
+
+	var in io.ReadCloser
+	if pre, ok := opened[fname]; ok {
+		delete(opened, fname)
+		in, ownFilename = pre.in, pre.ownFilename
+	} else {
+		var err error
+		if in, err = doc.Reader(); err != nil {
 			return fmt.Errorf("getting %s reader: %w", fname, err)
 		}
+	}
+	defer func() {
+		_ = in.Close()
+	}()
 
-		part, err := writer.CreateFormFile("files", fname)
-		if err != nil {
-			_ = in.Close()
+	if ownFilename {
+		fname = doc.Filename()
+	}
 
-			return fmt.Errorf("creating %s form file: %w", fname, err)
+	// The first bytes are used to detect the content type and check the content, without consuming them.
+	buffered := bufio.NewReaderSize(fw.counter.document(fname, in), document.SniffLen)
+
+	head, err := buffered.Peek(document.SniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		var limitErr *SizeLimitError
+		if errors.As(err, &limitErr) {
+			return err
 		}
 
-		if _, err = io.Copy(part, in); err != nil {
-			_ = in.Close()
+		return fmt.Errorf("reading %s data: %w", fname, err)
+	}
 
-			return fmt.Errorf("copying %s data: %w", fname, err)
+	if fw.checkContent {
+		if err = document.CheckContent(fname, head); err != nil {
+			return err
 		}
 	}
 
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="files"; filename="%s"`, quoteEscaper.Replace(fname)))
+	header.Set("Content-Type", document.DetectContentType(doc, head))
+
+	part, err := fw.writer.CreatePart(header)
+	if err != nil {
+		return fmt.Errorf("creating %s form file: %w", fname, err)
+	}
+
+	if _, err = io.Copy(part, buffered); err != nil {
+		return fmt.Errorf("copying %s data: %w", fname, err)
+	}
+
 	return nil
 }
@@ -0,0 +1,166 @@
+package gotenberg
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestMultipartFormRemoteDocument(t *testing.T) {
+	files := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Disposition", `attachment; filename="report.txt"`)
+		_, _ = w.Write([]byte("txt"))
+	}))
+	defer files.Close()
+
+	received := make(map[string]string)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reader, err := r.MultipartReader()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		for {
+			part, err := reader.NextPart()
+			if err != nil {
+				break
+			}
+
+			data, _ := io.ReadAll(part)
+			if part.FileName() != "" {
+				received[part.FileName()] = string(data)
+				received[part.FileName()+":type"] = part.Header.Get("Content-Type")
+			} else {
+				received[part.FormName()] = string(data)
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	doc, err := document.FromURL(context.Background(), files.URL+"/download", nil)
+	require.NoError(t, err)
+
+	c, err := NewClient(srv.URL, nil)
+	require.NoError(t, err)
+
+	req := NewLibreOfficeRequest(doc)
+	req.Landscape()
+
+	resp, err := c.Send(context.Background(), req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, map[string]string{
+		"report.txt":      "txt",
+		"report.txt:type": "text/plain; charset=utf-8",
+		"landscape":       "true",
+	}, received)
+}
+
+func TestMultipartFormResolvedFilenames(t *testing.T) {
+	files := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Disposition", `attachment; filename="`+r.URL.Query().Get("name")+`"`)
+		_, _ = w.Write([]byte("%PDF-1.7"))
+	}))
+	defer files.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil)
+	require.NoError(t, err)
+
+	var n int
+	remote := func(name string) document.Document {
+		n++
+		doc, err := document.FromURL(context.Background(), fmt.Sprintf("%s/files/%d?name=%s", files.URL, n, name), nil)
+		require.NoError(t, err)
+
+		return doc
+	}
+
+	// The filenames only known once fetched are validated before the form is sent.
+	var verr *ValidationError
+
+	_, err = c.Send(context.Background(), NewMergeRequest(remote("a.pdf"), remote("a.pdf")))
+	require.ErrorAs(t, err, &verr)
+	assert.ErrorContains(t, err, "a.pdf: several documents have the same filename")
+
+	_, err = c.Send(context.Background(), NewMergeRequest(remote("a.pdf"), remote("b.exe")))
+	require.ErrorAs(t, err, &verr)
+	assert.ErrorContains(t, err, "b.exe: must have one of the extensions .pdf")
+
+	resp, err := c.Send(context.Background(), NewMergeRequest(remote("a.pdf"), remote("b.pdf")))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+}
+
+func TestMultipartFormDocumentError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	doc, err := document.FromURL(context.Background(), srv.URL+"/missing.docx", &document.URLOptions{
+		HTTPClient: &http.Client{Transport: http.NewFileTransport(http.Dir(t.TempDir()))},
+	})
+	require.NoError(t, err)
+
+	c, err := NewClient(srv.URL, nil)
+	require.NoError(t, err)
+
+	_, err = c.Send(context.Background(), NewLibreOfficeRequest(doc))
+	require.ErrorIs(t, err, errSendRequestFailed)
+	assert.ErrorContains(t, err, "missing.docx")
+}
+
+func TestMultipartFormContentCheck(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	_, err := zw.Create("evil.exe")
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	doc, err := document.FromBytes("fake.docx", archive.Bytes())
+	require.NoError(t, err)
+
+	c, err := NewClient(srv.URL, nil)
+	require.NoError(t, err)
+
+	_, err = c.Send(context.Background(), NewLibreOfficeRequest(doc))
+
+	var mismatch *document.ContentMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, "fake.docx", mismatch.Filename)
+
+	c, err = NewClient(srv.URL, nil, WithoutContentCheck())
+	require.NoError(t, err)
+
+	resp, err := c.Send(context.Background(), NewLibreOfficeRequest(doc))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,254 @@
+package gotenberg
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PageRange is an inclusive range of pages, numbered from 1. An End of 0 means
+// the range is open and runs until the last page of the document.
+type PageRange struct {
+	Start int
+	End   int
+}
+
+// Range returns the pages from start to end, both inclusive.
+func Range(start, end int) PageRange {
+	return PageRange{Start: start, End: end}
+}
+
+// Page returns a single page.
+func Page(n int) PageRange {
+	return PageRange{Start: n, End: n}
+}
+
+// Open returns the pages from start until the last page of the document.
+func Open(start int) PageRange {
+	return PageRange{Start: start}
+}
+
+// IsOpen reports whether the range runs until the last page of the document.
+func (r PageRange) IsOpen() bool {
+	return r.End == 0
+}
+
+func (r PageRange) String() string {
+	switch {
+	case r.IsOpen():
+		return strconv.Itoa(r.Start) + "-"
+	case r.Start == r.End:
+		return strconv.Itoa(r.Start)
+	default:
+		return strconv.Itoa(r.Start) + "-" + strconv.Itoa(r.End)
+	}
+}
+
+func (r PageRange) validate() error {
+	if r.Start < 1 {
+		return fmt.Errorf("page %d is out of range, pages are numbered from 1", r.Start)
+	}
+
+	if !r.IsOpen() && r.End < r.Start {
+		return fmt.Errorf("range %d-%d starts after it ends", r.Start, r.End)
+	}
+
+	return nil
+}
+
+// PageRanges is a set of page ranges, e.g. "1-5,8,11-13". An empty set means all pages.
+type PageRanges []PageRange
+
+// NewPageRanges validates the given ranges and returns them normalised.
+func NewPageRanges(ranges ...PageRange) (PageRanges, error) {
+	for _, r := range ranges {
+		if err := r.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return PageRanges(ranges).Normalize(), nil
+}
+
+// pageRangesField returns the form field value of typed page ranges: normalised if they are valid,
+// or as given otherwise, so that Validate reports them.
+func pageRangesField(ranges []PageRange) string {
+	normalized, err := NewPageRanges(ranges...)
+	if err != nil {
+		return PageRanges(ranges).String()
+	}
+
+	return normalized.String()
+}
+
+// PageRangeError describes a syntax error in a page ranges expression.
+type PageRangeError struct {
+	Input string
+	// Offset is the byte offset in Input at which the invalid range starts.
+	Offset int
+	Reason string
+}
+
+func (e *PageRangeError) Error() string {
+	return fmt.Sprintf("invalid page ranges %q at offset %d: %s", e.Input, e.Offset, e.Reason)
+}
+
+// ParsePageRanges parses a page ranges expression such as "1-5, 8, 11-13". Besides single
+// pages and closed ranges, it accepts open ranges like "11-", which run until the last page,
+// and "-5", which start at the first page. The result is normalised.
+func ParsePageRanges(s string) (PageRanges, error) {
+	if strings.TrimSpace(s) == "" {
+		return PageRanges{}, nil
+	}
+
+	var (
+		ranges = make(PageRanges, 0, strings.Count(s, ",")+1)
+		offset int
+	)
+
+	for _, part := range strings.Split(s, ",") {
+		r, err := parsePageRange(part)
+		if err != nil {
+			return nil, &PageRangeError{
+				Input:  s,
+				Offset: offset + len(part) - len(strings.TrimLeft(part, " \t")),
+				Reason: err.Error(),
+			}
+		}
+
+		ranges = append(ranges, r)
+		offset += len(part) + 1
+	}
+
+	return ranges.Normalize(), nil
+}
+
+// MustParsePageRanges is like ParsePageRanges but panics if the expression cannot be parsed.
+func MustParsePageRanges(s string) PageRanges {
+	ranges, err := ParsePageRanges(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return ranges
+}
+
+func parsePageRange(part string) (PageRange, error) {
+	part = strings.TrimSpace(part)
+	if part == "" {
+		return PageRange{}, errors.New("empty range")
+	}
+
+	from, to, isRange := strings.Cut(part, "-")
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+
+	if !isRange {
+		n, err := parsePageNumber(from)
+		if err != nil {
+			return PageRange{}, err
+		}
+
+		return Page(n), nil
+	}
+
+	if from == "" && to == "" {
+		return PageRange{}, fmt.Errorf("range %q has no bounds", part)
+	}
+
+	start := 1
+	if from != "" {
+		n, err := parsePageNumber(from)
+		if err != nil {
+			return PageRange{}, err
+		}
+		start = n
+	}
+
+	if to == "" {
+		return Open(start), nil
+	}
+
+	end, err := parsePageNumber(to)
+	if err != nil {
+		return PageRange{}, err
+	}
+
+	r := Range(start, end)
+	if err = r.validate(); err != nil {
+		return PageRange{}, err
+	}
+
+	return r, nil
+}
+
+func parsePageNumber(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a page number", s)
+	}
+
+	if n < 1 {
+		return 0, fmt.Errorf("page %d is out of range, pages are numbered from 1", n)
+	}
+
+	return n, nil
+}
+
+// Normalize returns the ranges sorted by their first page, with overlapping
+// and adjacent ranges merged. The receiver is left untouched.
+func (pr PageRanges) Normalize() PageRanges {
+	if len(pr) == 0 {
+		return PageRanges{}
+	}
+
+	sorted := slices.Clone(pr)
+	slices.SortFunc(sorted, func(a, b PageRange) int {
+		return a.Start - b.Start
+	})
+
+	merged := PageRanges{sorted[0]}
+	for _, r := range sorted[1:] {
+		last := &merged[len(merged)-1]
+
+		switch {
+		case last.IsOpen():
+			// An open range already covers everything after it.
+		case r.Start <= last.End+1:
+			if r.IsOpen() || r.End > last.End {
+				last.End = r.End
+			}
+		default:
+			merged = append(merged, r)
+		}
+	}
+
+	return merged
+}
+
+// Contains reports whether the given page is covered by the ranges.
+// An empty set covers every page.
+func (pr PageRanges) Contains(page int) bool {
+	if len(pr) == 0 {
+		return page >= 1
+	}
+
+	for _, r := range pr {
+		if page >= r.Start && (r.IsOpen() || page <= r.End) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns the ranges in the syntax accepted by Gotenberg, e.g. "1-5,8,11-".
+func (pr PageRanges) String() string {
+	parts := make([]string, 0, len(pr))
+	for _, r := range pr {
+		parts = append(parts, r.String())
+	}
+
+	return strings.Join(parts, ",")
+}
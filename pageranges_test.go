@@ -0,0 +1,92 @@
+package gotenberg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePageRanges(t *testing.T) {
+	tests := []struct {
+		input string
+		want  PageRanges
+		str   string
+	}{
+		{input: "", want: PageRanges{}, str: ""},
+		{input: "1-5, 8, 11-13", want: PageRanges{Range(1, 5), Page(8), Range(11, 13)}, str: "1-5,8,11-13"},
+		{input: "11-", want: PageRanges{Open(11)}, str: "11-"},
+		{input: "-3", want: PageRanges{Range(1, 3)}, str: "1-3"},
+		{input: "8, 1-5, 3-6, 7", want: PageRanges{Range(1, 8)}, str: "1-8"},
+		{input: "2-4, 10-, 12-20", want: PageRanges{Range(2, 4), Open(10)}, str: "2-4,10-"},
+		{input: "1-1", want: PageRanges{Page(1)}, str: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParsePageRanges(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.str, got.String())
+		})
+	}
+}
+
+func TestParsePageRangesErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int
+	}{
+		{input: "1-5,,8", offset: 4},
+		{input: "1-5, a", offset: 5},
+		{input: "0", offset: 0},
+		{input: "1, 5-2", offset: 3},
+		{input: "1, -", offset: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := ParsePageRanges(tt.input)
+
+			var perr *PageRangeError
+			require.ErrorAs(t, err, &perr)
+			assert.Equal(t, tt.input, perr.Input)
+			assert.Equal(t, tt.offset, perr.Offset)
+		})
+	}
+}
+
+func TestNewPageRanges(t *testing.T) {
+	ranges, err := NewPageRanges(Page(8), Range(1, 5), Range(4, 6))
+	require.NoError(t, err)
+	assert.Equal(t, "1-6,8", ranges.String())
+	assert.True(t, ranges.Contains(8))
+	assert.False(t, ranges.Contains(7))
+
+	_, err = NewPageRanges(Range(3, 1))
+	require.Error(t, err)
+}
+
+func TestPageRangesSetters(t *testing.T) {
+	req := NewURLRequest("https://example.com")
+	req.NativePageRangesOf(Range(1, 5), Page(8), Open(11))
+	assert.Equal(t, "1-5,8,11-", req.fields[fieldChromiumNativePageRanges])
+
+	req.NativePageRangesOf(Page(0))
+	require.Error(t, req.Validate())
+
+	split := NewSplitPagesRequest()
+	split.SplitSpanOf(MustParsePageRanges("3-4, 1")...)
+	assert.Equal(t, "1,3-4", split.fields[fieldSplitSpan])
+
+	// Typed ranges are normalised like parsed ones, and invalid ones are reported.
+	split.SplitSpanOf(Range(4, 6), Page(8), Range(1, 5))
+	assert.Equal(t, "1-6,8", split.fields[fieldSplitSpan])
+
+	office := NewLibreOfficeRequest()
+	office.NativePageRangesOf(Page(2), Range(1, 3))
+	assert.Equal(t, "1-3", office.fields[fieldOfficeNativePageRanges])
+
+	office.NativePageRangesOf(Range(5, 1))
+	require.ErrorContains(t, office.Validate(), string(fieldOfficeNativePageRanges))
+}
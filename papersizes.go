@@ -0,0 +1,190 @@
+package gotenberg
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// paperSizes maps the lowercase names accepted by ParsePaperSize to paper dimensions.
+// nolint: gochecknoglobals
+var paperSizes = map[string]PaperDimensions{
+	"a0":               A0,
+	"a1":               A1,
+	"a2":               A2,
+	"a3":               A3,
+	"a4":               A4,
+	"a5":               A5,
+	"a6":               A6,
+	"a7":               A7,
+	"a8":               A8,
+	"a9":               A9,
+	"a10":              A10,
+	"b0":               B0,
+	"b1":               B1,
+	"b2":               B2,
+	"b3":               B3,
+	"b4":               B4,
+	"b5":               B5,
+	"b6":               B6,
+	"b7":               B7,
+	"b8":               B8,
+	"b9":               B9,
+	"b10":              B10,
+	"c0":               C0,
+	"c1":               C1,
+	"c2":               C2,
+	"c3":               C3,
+	"c4":               C4,
+	"c5":               C5,
+	"c6":               C6,
+	"c7":               C7,
+	"c8":               C8,
+	"c9":               C9,
+	"c10":              C10,
+	"jis-b0":           JISB0,
+	"jis-b1":           JISB1,
+	"jis-b2":           JISB2,
+	"jis-b3":           JISB3,
+	"jis-b4":           JISB4,
+	"jis-b5":           JISB5,
+	"jis-b6":           JISB6,
+	"jis-b7":           JISB7,
+	"jis-b8":           JISB8,
+	"jis-b9":           JISB9,
+	"jis-b10":          JISB10,
+	"letter":           Letter,
+	"legal":            Legal,
+	"tabloid":          Tabloid,
+	"ledger":           Ledger,
+	"executive":        Executive,
+	"statement":        Statement,
+	"half-letter":      Statement,
+	"dl":               EnvelopeDL,
+	"envelope-dl":      EnvelopeDL,
+	"envelope-10":      Envelope10,
+	"envelope-9":       Envelope9,
+	"monarch":          EnvelopeMonarch,
+	"envelope-monarch": EnvelopeMonarch,
+}
+
+// ParsePaperSize returns the paper size for a name of the catalogue, such as "A4", "Letter"
+// or "JIS B5", or for explicit dimensions such as "210mm x 297mm" or "8.5x11in". The name
+// may be followed by "landscape" or "portrait", e.g. "A4 landscape". Names are case-insensitive.
+func ParsePaperSize(s string) (PaperDimensions, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+
+	orientation := ""
+	for _, o := range []string{"landscape", "portrait"} {
+		if trimmed, ok := strings.CutSuffix(name, o); ok {
+			name = strings.TrimRight(trimmed, " -_")
+			orientation = o
+
+			break
+		}
+	}
+
+	size, ok := paperSizes[strings.NewReplacer(" ", "-", "_", "-").Replace(name)]
+	if !ok {
+		var err error
+		if size, err = parsePaperDimensions(name); err != nil {
+			return PaperDimensions{}, fmt.Errorf("parse paper size %q: %w", s, err)
+		}
+	}
+
+	switch orientation {
+	case "landscape":
+		return size.Landscape(), nil
+	case "portrait":
+		return size.Portrait(), nil
+	default:
+		return size, nil
+	}
+}
+
+func parsePaperDimensions(s string) (PaperDimensions, error) {
+	w, h, ok := strings.Cut(s, "x")
+	if !ok {
+		return PaperDimensions{}, errors.New("unknown paper size, expected a name or WIDTHxHEIGHT")
+	}
+
+	height, err := ParseLength(h)
+	if err != nil {
+		return PaperDimensions{}, err
+	}
+
+	// The width may omit its unit when it matches the height's, e.g. "210x297mm".
+	if _, err = strconv.ParseFloat(strings.TrimSpace(w), 64); err == nil {
+		w += string(height.Unit)
+	}
+
+	width, err := ParseLength(w)
+	if err != nil {
+		return PaperDimensions{}, err
+	}
+
+	if width.Value <= 0 || height.Value <= 0 {
+		return PaperDimensions{}, errors.New("dimensions must be positive")
+	}
+
+	if width, err = width.ToUnit(height.Unit); err != nil {
+		return PaperDimensions{}, err
+	}
+
+	return PaperDimensions{
+		Width:  width.Value,
+		Height: height.Value,
+		Unit:   height.Unit,
+	}, nil
+}
+
+// ToUnit converts the paper dimensions to the given unit. Both units must be valid.
+func (p PaperDimensions) ToUnit(unit SizeUnit) (PaperDimensions, error) {
+	ratio, err := unitRatio(p.Unit, unit)
+	if err != nil {
+		return PaperDimensions{}, err
+	}
+
+	return PaperDimensions{
+		Width:  p.Width * ratio,
+		Height: p.Height * ratio,
+		Unit:   unit,
+	}, nil
+}
+
+// IsLandscape reports whether the paper is wider than it is high.
+func (p PaperDimensions) IsLandscape() bool {
+	return p.Width > p.Height
+}
+
+// Landscape returns the paper turned so that it is wider than it is high.
+func (p PaperDimensions) Landscape() PaperDimensions {
+	if p.Width < p.Height {
+		p.Width, p.Height = p.Height, p.Width
+	}
+
+	return p
+}
+
+// Portrait returns the paper turned so that it is higher than it is wide.
+func (p PaperDimensions) Portrait() PaperDimensions {
+	if p.Width > p.Height {
+		p.Width, p.Height = p.Height, p.Width
+	}
+
+	return p
+}
+
+// PaperSizeNames returns the sorted names of the paper sizes known by ParsePaperSize.
+func PaperSizeNames() []string {
+	names := make([]string, 0, len(paperSizes))
+	for name := range paperSizes {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
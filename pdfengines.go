@@ -48,6 +48,19 @@ func (req *MergeRequest) Metadata(md []byte) {
 	req.fields[fieldMetadata] = string(md)
 }
 
+// Validate checks the request options before it is sent.
+func (req *MergeRequest) Validate() error {
+	v := &validator{}
+	v.documents("files", req.pdfs, req.requiredDocuments(1), ".pdf")
+	v.validateBase(req.baseRequest)
+
+	if raw, ok := req.fields[fieldMergePdfA]; ok {
+		v.pdfA(string(fieldMergePdfA), raw)
+	}
+
+	return v.err()
+}
+
 // Compile-time checks to ensure type implements desired interfaces.
 var (
 	_ = multipartRequester(new(MergeRequest))
@@ -0,0 +1,335 @@
+package gotenberg
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+var (
+	errEmptyPipeline = errors.New("pipeline has no steps")
+	errNoStepRequest = errors.New("step built no request")
+)
+
+const contentTypeZip = "application/zip"
+
+// Result is a resulting file held in memory.
+type Result struct {
+	// Filename is the output filename, with its extension.
+	Filename string
+	// ContentType is the MIME type of the file.
+	ContentType string
+	// Trace identifies the request in Gotenberg's logs.
+	Trace string
+	Data  []byte
+}
+
+// Document returns the result as a document, to send it in another request.
+func (r *Result) Document() (document.Document, error) {
+	return document.FromBytes(r.Filename, r.Data)
+}
+
+// IsArchive reports whether the result is a zip archive, as Gotenberg replies when a request
+// produces several files.
+func (r *Result) IsArchive() bool {
+	return r.ContentType == contentTypeZip || strings.EqualFold(path.Ext(r.Filename), ".zip")
+}
+
+// Files returns the files of a zip archive, sorted by name, or the result itself if it is not an archive.
+func (r *Result) Files() ([]*Result, error) {
+	if !r.IsArchive() {
+		return []*Result{r}, nil
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(r.Data), int64(len(r.Data)))
+	if err != nil {
+		return nil, fmt.Errorf("reading archive %s: %w", r.Filename, err)
+	}
+
+	files := make([]*Result, 0, len(zr.File))
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		data, err := readZipFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s in archive %s: %w", f.Name, r.Filename, err)
+		}
+
+		fname := path.Base(f.Name)
+		files = append(files, &Result{
+			Filename:    fname,
+			ContentType: mime.TypeByExtension(path.Ext(fname)),
+			Trace:       r.Trace,
+			Data:        data,
+		})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Filename < files[j].Filename
+	})
+
+	return files, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	return io.ReadAll(rc)
+}
+
+// Fetch sends a request and reads its resulting file in memory.
+func (c *Client) Fetch(ctx context.Context, req multipartRequester) (*Result, error) {
+	if hasWebhook(req) {
+		return nil, errWebhookNotAllowed
+	}
+
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorMessageSize))
+
+		return nil, fmt.Errorf("%w: %d: %s", errGenerationFailed, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading result: %w", err)
+	}
+
+	return &Result{
+		Filename:    document.DispositionFilename(resp.Header.Get("Content-Disposition")),
+		ContentType: resp.Header.Get("Content-Type"),
+		Trace:       resp.Header.Get(string(headerTrace)),
+		Data:        data,
+	}, nil
+}
+
+// maxErrorMessageSize bounds how much of an error reply is kept in the error message.
+const maxErrorMessageSize = 4096
+
+// StepFunc builds the request of a pipeline step from the documents produced by the previous step.
+type StepFunc func(docs []document.Document) (Requester, error)
+
+// PipelineError tells which step of a pipeline failed.
+type PipelineError struct {
+	// Step is the position of the step in the pipeline, from 0.
+	Step int
+	Name string
+	Err  error
+}
+
+func (e *PipelineError) Error() string {
+	return fmt.Sprintf("pipeline step %d (%s): %v", e.Step, e.Name, e.Err)
+}
+
+func (e *PipelineError) Unwrap() error {
+	return e.Err
+}
+
+type pipelineStep struct {
+	name  string
+	build StepFunc
+	join  bool
+}
+
+// Pipeline chains requests, feeding the resulting files of a step to the next one in memory.
+//
+// A step which replies with a zip archive fans out: the following steps run once for each file of the
+// archive, until a joining step, such as Merge, gathers the files again.
+type Pipeline struct {
+	client *Client
+	steps  []pipelineStep
+}
+
+// NewPipeline creates an empty pipeline run by c.
+func NewPipeline(c *Client) *Pipeline {
+	return &Pipeline{client: c}
+}
+
+// Then adds a step which runs once for each file produced by the previous step. The first step of a
+// pipeline gets no documents.
+func (p *Pipeline) Then(name string, build StepFunc) *Pipeline {
+	p.steps = append(p.steps, pipelineStep{name: name, build: build})
+
+	return p
+}
+
+// Join adds a step which runs once with all the files produced by the previous step.
+func (p *Pipeline) Join(name string, build StepFunc) *Pipeline {
+	p.steps = append(p.steps, pipelineStep{name: name, build: build, join: true})
+
+	return p
+}
+
+// Start adds a step sending req, typically the first one.
+func (p *Pipeline) Start(name string, req Requester) *Pipeline {
+	return p.Join(name, func([]document.Document) (Requester, error) {
+		return req, nil
+	})
+}
+
+// Merge adds a step merging all the files produced by the previous step, in order, followed by extra.
+// Files are renamed with a numeric prefix to keep their order, as Gotenberg merges them alphabetically.
+func (p *Pipeline) Merge(extra ...document.Document) *Pipeline {
+	return p.Join("merge", func(docs []document.Document) (Requester, error) {
+		docs = append(docs, extra...)
+
+		// Pad the prefixes to the same width, so that they sort as numbers.
+		width := len(strconv.Itoa(len(docs) - 1))
+
+		ordered := make([]document.Document, 0, len(docs))
+		for i, doc := range docs {
+			ordered = append(ordered, document.Rename(doc, fmt.Sprintf("%0*d_%s", width, i, doc.Filename())))
+		}
+
+		return NewMergeRequest(ordered...), nil
+	})
+}
+
+// WriteMetadata adds a step writing metadata to each file produced by the previous step.
+func (p *Pipeline) WriteMetadata(md []byte) *Pipeline {
+	return p.Then("write-metadata", func(docs []document.Document) (Requester, error) {
+		req := NewWriteMetadataRequest(docs...)
+		req.Metadata(md)
+
+		return req, nil
+	})
+}
+
+// Flatten adds a step flattening each file produced by the previous step.
+func (p *Pipeline) Flatten() *Pipeline {
+	return p.Then("flatten", func(docs []document.Document) (Requester, error) {
+		return newFlattenRequest(docs...), nil
+	})
+}
+
+// Run runs the steps in order and returns the files produced by the last one. Archives produced by the
+// last step are returned as they are. A failure is returned as a *PipelineError.
+func (p *Pipeline) Run(ctx context.Context) ([]*Result, error) {
+	if len(p.steps) == 0 {
+		return nil, errEmptyPipeline
+	}
+
+	var results []*Result
+
+	for i, step := range p.steps {
+		if i > 0 {
+			var err error
+			if results, err = expandResults(results); err != nil {
+				return nil, &PipelineError{Step: i - 1, Name: p.steps[i-1].name, Err: err}
+			}
+		}
+
+		next, err := p.run(ctx, i, step, results)
+		if err != nil {
+			return nil, &PipelineError{Step: i, Name: step.name, Err: err}
+		}
+
+		results = next
+	}
+
+	return results, nil
+}
+
+func (p *Pipeline) run(ctx context.Context, index int, step pipelineStep, inputs []*Result) ([]*Result, error) {
+	if step.join || len(inputs) == 0 {
+		result, err := p.fetch(ctx, step, inputs)
+		if err != nil {
+			return nil, err
+		}
+
+		defaultFilename(result, fmt.Sprintf("step-%d", index))
+
+		return []*Result{result}, nil
+	}
+
+	results := make([]*Result, 0, len(inputs))
+	for i, input := range inputs {
+		result, err := p.fetch(ctx, step, []*Result{input})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", input.Filename, err)
+		}
+
+		defaultFilename(result, fmt.Sprintf("step-%d-%d", index, i))
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// defaultFilename names a result which Gotenberg sent without a Content-Disposition header, so that
+// the next step gets a document with a filename.
+func defaultFilename(result *Result, name string) {
+	if result.Filename != "" {
+		return
+	}
+
+	if result.ContentType == contentTypeZip {
+		result.Filename = name + ".zip"
+	} else {
+		result.Filename = name + ".pdf"
+	}
+}
+
+func (p *Pipeline) fetch(ctx context.Context, step pipelineStep, inputs []*Result) (*Result, error) {
+	docs := make([]document.Document, 0, len(inputs))
+	for _, input := range inputs {
+		doc, err := input.Document()
+		if err != nil {
+			return nil, err
+		}
+
+		docs = append(docs, doc)
+	}
+
+	req, err := step.build(docs)
+	if err != nil {
+		return nil, err
+	}
+
+	if req == nil {
+		return nil, errNoStepRequest
+	}
+
+	return p.client.Fetch(ctx, req)
+}
+
+// expandResults replaces the archives among results with their files.
+func expandResults(results []*Result) ([]*Result, error) {
+	expanded := make([]*Result, 0, len(results))
+	for _, result := range results {
+		files, err := result.Files()
+		if err != nil {
+			return nil, err
+		}
+
+		expanded = append(expanded, files...)
+	}
+
+	return expanded, nil
+}
@@ -0,0 +1,172 @@
+package gotenberg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+	"github.com/starwalkn/gotenberg-go-client/v8/gotenbergtest"
+)
+
+func newPipelineClient(t *testing.T) (*Client, *gotenbergtest.Server) {
+	t.Helper()
+
+	srv := gotenbergtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(srv.URL, srv.Client())
+	require.NoError(t, err)
+
+	return c, srv
+}
+
+func requestPaths(srv *gotenbergtest.Server) []string {
+	var paths []string
+	for _, req := range srv.Requests() {
+		paths = append(paths, req.Path)
+	}
+
+	return paths
+}
+
+func TestPipeline(t *testing.T) {
+	c, srv := newPipelineClient(t)
+
+	index, err := document.FromString("index.html", "<html>Invoice</html>")
+	require.NoError(t, err)
+	terms, err := document.FromBytes("terms.pdf", gotenbergtest.PDF(2))
+	require.NoError(t, err)
+
+	results, err := NewPipeline(c).
+		Start("html", NewHTMLRequest(index)).
+		Merge(terms).
+		WriteMetadata([]byte(`{"Author":"Billing"}`)).
+		Flatten().
+		Run(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, bytes.HasPrefix(results[0].Data, []byte("%PDF-")))
+	assert.Equal(t, "application/pdf", results[0].ContentType)
+
+	assert.Equal(t, []string{
+		gotenbergtest.RouteHTMLConvert,
+		gotenbergtest.RouteMerge,
+		gotenbergtest.RouteMetadataWrite,
+		gotenbergtest.RouteFlatten,
+	}, requestPaths(srv))
+
+	merge := srv.Requests()[1]
+	require.Len(t, merge.Files, 2)
+	assert.Equal(t, gotenbergtest.PDF(2), merge.Files["1_terms.pdf"])
+}
+
+func TestPipelineMergeOrder(t *testing.T) {
+	c, srv := newPipelineClient(t)
+
+	index, err := document.FromString("index.html", "<html>Invoice</html>")
+	require.NoError(t, err)
+
+	extra := make([]document.Document, 0, 10)
+	for range 10 {
+		pdf, err := document.FromBytes("annex.pdf", gotenbergtest.PDF(1))
+		require.NoError(t, err)
+
+		extra = append(extra, pdf)
+	}
+
+	_, err = NewPipeline(c).Start("html", NewHTMLRequest(index)).Merge(extra...).Run(context.Background())
+	require.NoError(t, err)
+
+	// Prefixes have the same width, so that Gotenberg's alphabetical order is the pipeline's order.
+	var prefixes []string
+	for fname := range srv.Requests()[1].Files {
+		prefix, _, _ := strings.Cut(fname, "_")
+		prefixes = append(prefixes, prefix)
+	}
+
+	assert.ElementsMatch(t, []string{"00", "01", "02", "03", "04", "05", "06", "07", "08", "09", "10"}, prefixes)
+}
+
+func TestPipelineFanOut(t *testing.T) {
+	c, srv := newPipelineClient(t)
+
+	pdf, err := document.FromBytes("report.pdf", gotenbergtest.PDF(3))
+	require.NoError(t, err)
+
+	split := NewSplitIntervalsRequest(pdf)
+	split.SplitSpan(1)
+
+	results, err := NewPipeline(c).Start("split", split).Flatten().Run(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, results, 3)
+
+	results, err = NewPipeline(c).Start("split", split).Flatten().Merge().Run(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	last, ok := srv.LastRequest()
+	require.True(t, ok)
+	assert.Equal(t, gotenbergtest.RouteMerge, last.Path)
+	assert.Len(t, last.Files, 3)
+}
+
+func TestPipelineDefaultFilename(t *testing.T) {
+	var uploaded []string
+
+	// Reply without a Content-Disposition header, which Gotenberg may omit.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err == nil {
+			for _, files := range r.MultipartForm.File {
+				uploaded = append(uploaded, files[0].Filename)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write(gotenbergtest.PDF(1))
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(srv.URL, srv.Client())
+	require.NoError(t, err)
+
+	pdf, err := document.FromBytes("report.pdf", gotenbergtest.PDF(1))
+	require.NoError(t, err)
+
+	results, err := NewPipeline(c).Start("merge", NewMergeRequest(pdf)).Flatten().Run(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "step-1-0.pdf", results[0].Filename)
+	assert.Equal(t, []string{"report.pdf", "step-0.pdf"}, uploaded)
+}
+
+func TestPipelineStepError(t *testing.T) {
+	c, srv := newPipelineClient(t)
+
+	srv.SetResponse(gotenbergtest.RouteFlatten, gotenbergtest.Response{
+		Status: http.StatusBadRequest,
+		Body:   []byte("Invalid form data"),
+	})
+
+	pdf, err := document.FromBytes("report.pdf", gotenbergtest.PDF(1))
+	require.NoError(t, err)
+
+	_, err = NewPipeline(c).Start("merge", NewMergeRequest(pdf)).Flatten().Run(context.Background())
+	require.ErrorIs(t, err, errGenerationFailed)
+	assert.ErrorContains(t, err, "Invalid form data")
+
+	var pipelineErr *PipelineError
+	require.True(t, errors.As(err, &pipelineErr))
+	assert.Equal(t, 1, pipelineErr.Step)
+	assert.Equal(t, "flatten", pipelineErr.Name)
+
+	_, err = NewPipeline(c).Run(context.Background())
+	require.ErrorIs(t, err, errEmptyPipeline)
+}
@@ -0,0 +1,153 @@
+package gotenberg
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ProgressPhase is the stage of a request reported to a ProgressFunc.
+type ProgressPhase int
+
+const (
+	// PhaseUploading is reported while the documents and form fields are uploaded.
+	PhaseUploading ProgressPhase = iota + 1
+	// PhaseWaiting is reported once the request is uploaded, while Gotenberg processes it.
+	PhaseWaiting
+	// PhaseDownloading is reported while the result is received.
+	PhaseDownloading
+)
+
+func (p ProgressPhase) String() string {
+	switch p {
+	case PhaseUploading:
+		return "uploading"
+	case PhaseWaiting:
+		return "waiting"
+	case PhaseDownloading:
+		return "downloading"
+	default:
+		return "unknown"
+	}
+}
+
+// Progress describes how far a request has gone.
+type Progress struct {
+	Phase ProgressPhase
+	// Document is the filename of the document being uploaded, if any.
+	Document string
+	// DocumentBytes is the number of bytes of Document uploaded so far.
+	DocumentBytes int64
+	// Uploaded is the number of bytes of the request body uploaded so far.
+	Uploaded int64
+	// Downloaded is the number of bytes of the response body received so far.
+	Downloaded int64
+	// DownloadSize is the size of the response body, or -1 if Gotenberg did not send it.
+	DownloadSize int64
+}
+
+// ProgressFunc receives the progress of a request. Calls are never concurrent, but they happen
+// on the goroutine which uploads the request as well as on the one which reads the response,
+// so the function must return quickly.
+type ProgressFunc func(Progress)
+
+// WithProgress reports the progress of every request sent by the client to fn.
+func WithProgress(fn ProgressFunc) ClientOption {
+	return func(c *Client) {
+		c.progress = fn
+	}
+}
+
+// progressReporter keeps the progress of a request and passes every change to its functions.
+// A nil reporter ignores all changes, so that requests without progress functions cost nothing.
+type progressReporter struct {
+	fns []ProgressFunc
+
+	mu       sync.Mutex
+	progress Progress
+}
+
+func newProgressReporter(fns ...ProgressFunc) *progressReporter {
+	pr := &progressReporter{progress: Progress{Phase: PhaseUploading, DownloadSize: -1}}
+
+	for _, fn := range fns {
+		if fn != nil {
+			pr.fns = append(pr.fns, fn)
+		}
+	}
+
+	if len(pr.fns) == 0 {
+		return nil
+	}
+
+	return pr
+}
+
+// report applies update to the progress and notifies the functions, unless the progress
+// is already past phase, which happens when Gotenberg replies before the upload ends.
+func (pr *progressReporter) report(phase ProgressPhase, update func(p *Progress)) {
+	if pr == nil {
+		return
+	}
+
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+
+	if pr.progress.Phase > phase {
+		return
+	}
+
+	pr.progress.Phase = phase
+	update(&pr.progress)
+
+	for _, fn := range pr.fns {
+		fn(pr.progress)
+	}
+}
+
+func (pr *progressReporter) uploaded(doc string, docBytes, total int64) {
+	pr.report(PhaseUploading, func(p *Progress) {
+		p.Document = doc
+		p.DocumentBytes = docBytes
+		p.Uploaded = total
+	})
+}
+
+func (pr *progressReporter) waiting() {
+	pr.report(PhaseWaiting, func(p *Progress) {
+		p.Document = ""
+		p.DocumentBytes = 0
+	})
+}
+
+// download reports the start of the response and wraps its body to report the bytes received.
+func (pr *progressReporter) download(resp *http.Response) {
+	if pr == nil {
+		return
+	}
+
+	pr.report(PhaseDownloading, func(p *Progress) {
+		p.Document = ""
+		p.DocumentBytes = 0
+		p.DownloadSize = resp.ContentLength
+	})
+
+	resp.Body = &progressBody{ReadCloser: resp.Body, progress: pr}
+}
+
+type progressBody struct {
+	io.ReadCloser
+
+	progress *progressReporter
+}
+
+func (pb *progressBody) Read(p []byte) (int, error) {
+	n, err := pb.ReadCloser.Read(p)
+	if n > 0 {
+		pb.progress.report(PhaseDownloading, func(p *Progress) {
+			p.Downloaded += int64(n)
+		})
+	}
+
+	return n, err
+}
@@ -0,0 +1,81 @@
+package gotenberg
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func newResultServer(t *testing.T, result string) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		_, _ = w.Write([]byte(result))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestProgressStore(t *testing.T) {
+	srv := newResultServer(t, "%PDF-result")
+
+	doc, err := document.FromString("report.txt", strings.Repeat("x", 100_000))
+	require.NoError(t, err)
+
+	var reports []Progress
+	c, err := NewClient(srv.URL, nil, WithProgress(func(p Progress) {
+		reports = append(reports, p)
+	}))
+	require.NoError(t, err)
+
+	require.NoError(t, c.Store(context.Background(), NewLibreOfficeRequest(doc), filepath.Join(t.TempDir(), "out.pdf")))
+	require.NotEmpty(t, reports)
+
+	for i := 1; i < len(reports); i++ {
+		assert.GreaterOrEqual(t, reports[i].Phase, reports[i-1].Phase)
+	}
+
+	var lastUpload Progress
+	for _, p := range reports {
+		if p.Phase == PhaseUploading && p.Document == "report.txt" {
+			lastUpload = p
+		}
+	}
+	assert.Equal(t, int64(100_000), lastUpload.DocumentBytes)
+	assert.Greater(t, lastUpload.Uploaded, int64(100_000))
+
+	last := reports[len(reports)-1]
+	assert.Equal(t, PhaseDownloading, last.Phase)
+	assert.Equal(t, int64(len("%PDF-result")), last.Downloaded)
+	assert.Equal(t, int64(len("%PDF-result")), last.DownloadSize)
+}
+
+func TestProgressPerRequest(t *testing.T) {
+	srv := newResultServer(t, "png")
+
+	index, err := document.FromString("index.html", "<html>Hello</html>")
+	require.NoError(t, err)
+
+	c, err := NewClient(srv.URL, nil)
+	require.NoError(t, err)
+
+	phases := make(map[ProgressPhase]bool)
+	req := NewHTMLRequest(index)
+	req.OnProgress(func(p Progress) {
+		phases[p.Phase] = true
+	})
+
+	require.NoError(t, c.StoreScreenshot(context.Background(), req, filepath.Join(t.TempDir(), "out.png")))
+	assert.Equal(t, map[ProgressPhase]bool{PhaseUploading: true, PhaseWaiting: true, PhaseDownloading: true}, phases)
+}
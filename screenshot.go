@@ -3,33 +3,39 @@ package gotenberg
 import (
 	"context"
 	"fmt"
+	"image"
 	"net/http"
 )
 
 type screenshotRequester interface {
 	screenshotEndpoint() string
+	screenshotClipRect() *image.Rectangle
 
 	multipartRequester
 }
 
-func (c *Client) Screenshot(ctx context.Context, scr screenshotRequester) (*http.Response, error) {
-	return c.screenshot(ctx, scr)
+// ScreenshotRequester is implemented by the requests which can be sent as screenshots, i.e. HTML, URL and
+// markdown requests. It cannot be implemented outside of this package.
+type ScreenshotRequester interface {
+	screenshotRequester
 }
 
-func (c *Client) screenshot(ctx context.Context, scr screenshotRequester) (*http.Response, error) {
-	req, err := c.createRequest(ctx, scr, scr.screenshotEndpoint())
-	if err != nil {
+// Screenshot sends a screenshot request and returns the response. A rectangle set with ScreenshotClipRect
+// is only applied by ScreenshotImage, so it is rejected.
+func (c *Client) Screenshot(ctx context.Context, scr screenshotRequester) (*http.Response, error) {
+	if err := checkNoClipRect(scr); err != nil {
 		return nil, err
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, errSendRequestFailed
-	}
+	return c.screenshot(ctx, scr)
+}
 
-	return resp, nil
+func (c *Client) screenshot(ctx context.Context, scr screenshotRequester) (*http.Response, error) {
+	return c.do(ctx, scr, scr.screenshotEndpoint())
 }
 
+// StoreScreenshot writes the screenshot to dest. A rectangle set with ScreenshotClipRect is only applied by
+// ScreenshotImage, so it is rejected.
 func (c *Client) StoreScreenshot(ctx context.Context, req screenshotRequester, dest string) error {
 	return c.storeScreenshot(ctx, req, dest)
 }
@@ -39,6 +45,10 @@ func (c *Client) storeScreenshot(ctx context.Context, scr screenshotRequester, d
 		return errWebhookNotAllowed
 	}
 
+	if err := checkNoClipRect(scr); err != nil {
+		return err
+	}
+
 	resp, err := c.screenshot(ctx, scr)
 	if err != nil {
 		return err
@@ -53,3 +63,13 @@ func (c *Client) storeScreenshot(ctx context.Context, scr screenshotRequester, d
 
 	return writeNewFile(dest, resp.Body)
 }
+
+// checkNoClipRect reports a rectangle set with ScreenshotClipRect, which would be silently ignored.
+func checkNoClipRect(scr screenshotRequester) error {
+	v := &validator{}
+	if rect := scr.screenshotClipRect(); rect != nil {
+		v.addf("clipRect", "is only applied by ScreenshotImage, use it to clip to %v", *rect)
+	}
+
+	return v.err()
+}
@@ -0,0 +1,223 @@
+package gotenberg
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"maps"
+	"net/http"
+	"strings"
+)
+
+var (
+	errUnknownImageFormat = errors.New("unknown image format")
+	errEmptyClip          = errors.New("clip rectangle is outside of the screenshot")
+	errInvalidWebP        = errors.New("invalid WebP header")
+)
+
+// ScreenshotResult is a screenshot decoded in memory.
+type ScreenshotResult struct {
+	// Image is the decoded screenshot. It is nil for WebP, which the standard library cannot decode.
+	Image  image.Image
+	Format ImageFormat
+	Width  int
+	Height int
+	// Data is the encoded screenshot.
+	Data []byte
+}
+
+// ScreenshotImage takes a screenshot and decodes it. The rectangle set with ScreenshotClipRect, if any,
+// is cropped and the cropped image is encoded again.
+func (c *Client) ScreenshotImage(ctx context.Context, scr screenshotRequester) (*ScreenshotResult, error) {
+	if hasWebhook(scr) {
+		return nil, errWebhookNotAllowed
+	}
+
+	resp, err := c.screenshot(ctx, scr)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorMessageSize))
+
+		return nil, fmt.Errorf("%w: %d: %s", errGenerationFailed, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading screenshot: %w", err)
+	}
+
+	result, err := decodeScreenshot(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if rect := scr.screenshotClipRect(); rect != nil {
+		return result.crop(*rect)
+	}
+
+	return result, nil
+}
+
+// ScreenshotWidths takes a screenshot at each device width, with one call each, e.g. to preview how a
+// responsive page renders on phones, tablets and desktops. The page is laid out again at each width, so
+// the screenshots are not scaled copies of one another. The request itself is not modified.
+func (c *Client) ScreenshotWidths(
+	ctx context.Context,
+	scr screenshotRequester,
+	widths ...int,
+) ([]*ScreenshotResult, error) {
+	v := &validator{}
+	for _, w := range widths {
+		if w <= 0 {
+			v.addf(string(fieldScreenshotWidth), "must be a positive number of pixels, got %d", w)
+		}
+	}
+
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	results := make([]*ScreenshotResult, 0, len(widths))
+	for _, w := range widths {
+		fields := maps.Clone(scr.formFields())
+		fields[fieldScreenshotWidth] = fmt.Sprintf("%f", float64(w))
+
+		result, err := c.ScreenshotImage(ctx, &screenshotWithFields{screenshotRequester: scr, fields: fields})
+		if err != nil {
+			return nil, fmt.Errorf("screenshot at %dpx: %w", w, err)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// screenshotWithFields sends a screenshot request with other form fields, leaving the request unchanged.
+type screenshotWithFields struct {
+	screenshotRequester
+
+	fields map[formField]string
+}
+
+func (scr *screenshotWithFields) formFields() map[formField]string {
+	return scr.fields
+}
+
+func decodeScreenshot(data []byte) (*ScreenshotResult, error) {
+	result := &ScreenshotResult{Data: data}
+
+	var err error
+
+	switch http.DetectContentType(data) {
+	case "image/png":
+		result.Format = PNG
+		result.Image, err = png.Decode(bytes.NewReader(data))
+	case "image/jpeg":
+		result.Format = JPEG
+		result.Image, err = jpeg.Decode(bytes.NewReader(data))
+	case "image/webp":
+		result.Format = WebP
+		result.Width, result.Height, err = webpSize(data)
+
+		return result, err
+	default:
+		return nil, errUnknownImageFormat
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s screenshot: %w", result.Format, err)
+	}
+
+	bounds := result.Image.Bounds()
+	result.Width, result.Height = bounds.Dx(), bounds.Dy()
+
+	return result, nil
+}
+
+// crop returns the part of the screenshot within rect, encoded in the same format.
+func (r *ScreenshotResult) crop(rect image.Rectangle) (*ScreenshotResult, error) {
+	if r.Image == nil {
+		return nil, fmt.Errorf("cannot crop %s screenshots", r.Format)
+	}
+
+	rect = rect.Add(r.Image.Bounds().Min).Intersect(r.Image.Bounds())
+	if rect.Empty() {
+		return nil, errEmptyClip
+	}
+
+	cropped := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Draw(cropped, cropped.Bounds(), r.Image, rect.Min, draw.Src)
+
+	var buf bytes.Buffer
+
+	var err error
+	if r.Format == JPEG {
+		err = jpeg.Encode(&buf, cropped, &jpeg.Options{Quality: jpeg.DefaultQuality})
+	} else {
+		err = png.Encode(&buf, cropped)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("encoding cropped screenshot: %w", err)
+	}
+
+	return &ScreenshotResult{
+		Image:  cropped,
+		Format: r.Format,
+		Width:  rect.Dx(),
+		Height: rect.Dy(),
+		Data:   buf.Bytes(),
+	}, nil
+}
+
+// webpSize reads the dimensions of a WebP image from its first chunk, which is VP8X for extended images,
+// VP8L for lossless ones and VP8 for lossy ones.
+func webpSize(data []byte) (int, int, error) {
+	const chunkStart = 12
+
+	if len(data) < chunkStart+18 {
+		return 0, 0, fmt.Errorf("%w: truncated", errInvalidWebP)
+	}
+
+	payload := data[chunkStart+8:]
+
+	switch string(data[chunkStart : chunkStart+4]) {
+	case "VP8X":
+		return 1 + int(uint24(payload[4:7])), 1 + int(uint24(payload[7:10])), nil
+	case "VP8L":
+		if payload[0] != 0x2f {
+			return 0, 0, fmt.Errorf("%w: bad VP8L signature", errInvalidWebP)
+		}
+
+		bits := binary.LittleEndian.Uint32(payload[1:5])
+
+		return 1 + int(bits&0x3fff), 1 + int((bits>>14)&0x3fff), nil
+	case "VP8 ":
+		if !bytes.Equal(payload[3:6], []byte{0x9d, 0x01, 0x2a}) {
+			return 0, 0, fmt.Errorf("%w: bad VP8 start code", errInvalidWebP)
+		}
+
+		return int(binary.LittleEndian.Uint16(payload[6:8]) & 0x3fff),
+			int(binary.LittleEndian.Uint16(payload[8:10]) & 0x3fff), nil
+	default:
+		return 0, 0, fmt.Errorf("%w: unknown chunk", errInvalidWebP)
+	}
+}
+
+func uint24(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+}
@@ -0,0 +1,136 @@
+package gotenberg
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/gotenbergtest"
+)
+
+func newScreenshotClient(t *testing.T) (*Client, *gotenbergtest.Server) {
+	t.Helper()
+
+	srv := gotenbergtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(srv.URL, srv.Client())
+	require.NoError(t, err)
+
+	return c, srv
+}
+
+func TestScreenshotImage(t *testing.T) {
+	c, _ := newScreenshotClient(t)
+
+	req := NewURLRequest("https://example.com")
+	req.ScreenshotWidth(8)
+	req.ScreenshotHeight(4)
+
+	result, err := c.ScreenshotImage(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, PNG, result.Format)
+	assert.Equal(t, 8, result.Width)
+	assert.Equal(t, 4, result.Height)
+	require.NotNil(t, result.Image)
+	assert.Equal(t, image.Rect(0, 0, 8, 4), result.Image.Bounds())
+
+	req.Format(JPEG)
+
+	result, err = c.ScreenshotImage(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, JPEG, result.Format)
+	assert.Equal(t, 8, result.Width)
+
+	req.Format(WebP)
+
+	result, err = c.ScreenshotImage(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, WebP, result.Format)
+	assert.Nil(t, result.Image)
+	assert.Equal(t, 1, result.Width)
+	assert.Equal(t, 1, result.Height)
+	assert.Equal(t, gotenbergtest.WebP(), result.Data)
+}
+
+func TestScreenshotClipRect(t *testing.T) {
+	c, srv := newScreenshotClient(t)
+
+	req := NewURLRequest("https://example.com")
+	req.ScreenshotClipRect(image.Rect(6, 5, 2, 2))
+
+	result, err := c.ScreenshotImage(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.Width)
+	assert.Equal(t, 3, result.Height)
+
+	decoded, err := png.Decode(bytes.NewReader(result.Data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 4, 3), decoded.Bounds())
+
+	last, ok := srv.LastRequest()
+	require.True(t, ok)
+	assert.Equal(t, "true", last.Fields["clip"])
+	assert.Equal(t, "6.000000", last.Fields["width"])
+	assert.Equal(t, "5.000000", last.Fields["height"])
+
+	// Screenshot and StoreScreenshot cannot crop, so they reject the rectangle rather than ignore it.
+	_, err = c.Screenshot(context.Background(), req)
+	require.ErrorContains(t, err, "clipRect: is only applied by ScreenshotImage")
+	err = c.StoreScreenshot(context.Background(), req, filepath.Join(t.TempDir(), "out.png"))
+	require.ErrorContains(t, err, "clipRect: is only applied by ScreenshotImage")
+
+	req.Format(WebP)
+	require.ErrorContains(t, req.Validate(), "clipRect")
+}
+
+func TestScreenshotWidths(t *testing.T) {
+	c, srv := newScreenshotClient(t)
+
+	req := NewURLRequest("https://example.com")
+	req.ScreenshotWidth(12)
+	req.ScreenshotHeight(10)
+
+	results, err := c.ScreenshotWidths(context.Background(), req, 4, 8)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, 4, results[0].Width)
+	assert.Equal(t, 8, results[1].Width)
+	require.Len(t, srv.Requests(), 2)
+	assert.Equal(t, "4.000000", srv.Requests()[0].Fields["width"])
+	assert.Equal(t, "12.000000", req.fields[fieldScreenshotWidth])
+
+	// The request is left unchanged, so it can be shared between goroutines.
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := c.ScreenshotWidths(context.Background(), req, 4, 8)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	_, err = c.ScreenshotWidths(context.Background(), req, 0)
+	require.ErrorContains(t, err, "width")
+}
+
+func TestWebPSize(t *testing.T) {
+	width, height, err := webpSize(gotenbergtest.WebP())
+	require.NoError(t, err)
+	assert.Equal(t, 1, width)
+	assert.Equal(t, 1, height)
+
+	_, _, err = webpSize([]byte("RIFF"))
+	require.ErrorIs(t, err, errInvalidWebP)
+}
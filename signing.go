@@ -0,0 +1,172 @@
+package gotenberg
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	errWebhookSignature = errors.New("invalid webhook signature")
+	errWebhookExpired   = errors.New("webhook signature expired")
+	errWebhookReplayed  = errors.New("webhook call replayed")
+)
+
+const (
+	defaultWebhookSignatureTTL = time.Hour
+
+	signatureExpiresParam = "gotenberg-expires"
+	signatureNonceParam   = "gotenberg-nonce"
+	signatureParam        = "gotenberg-signature"
+)
+
+// WithWebhookSigning makes the client sign the webhook URLs of every request with an HMAC-SHA256 of key,
+// and an expiry timestamp ttl from now. A zero ttl means one hour. A WebhookHandler or Jobs configured
+// with VerifySignature and the same key then rejects calls which were not made with these URLs.
+func WithWebhookSigning(key []byte, ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.webhookSigner = newWebhookSigner(key, ttl)
+	}
+}
+
+// webhookSigner signs the path and query of webhook URLs. The host is left out of the signature,
+// as it often differs between the URL Gotenberg calls and the one the receiver sees behind a proxy.
+type webhookSigner struct {
+	key []byte
+	ttl time.Duration
+}
+
+func newWebhookSigner(key []byte, ttl time.Duration) *webhookSigner {
+	if ttl <= 0 {
+		ttl = defaultWebhookSignatureTTL
+	}
+
+	return &webhookSigner{key: key, ttl: ttl}
+}
+
+// signHeaders signs the webhook URLs of a request, replacing any previous signature.
+func (s *webhookSigner) signHeaders(headers map[httpHeader]string, now time.Time) error {
+	for _, header := range []httpHeader{headerWebhookURL, headerWebhookErrorURL, headerWebhookEventsURL} {
+		rawURL, ok := headers[header]
+		if !ok || rawURL == "" {
+			continue
+		}
+
+		signed, err := s.sign(rawURL, now)
+		if err != nil {
+			return err
+		}
+
+		headers[header] = signed
+	}
+
+	return nil
+}
+
+func (s *webhookSigner) sign(rawURL string, now time.Time) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("parsing webhook URL %s: %w", rawURL, err)
+	}
+
+	nonce := make([]byte, 16)
+	if _, err = rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generating webhook nonce: %w", err)
+	}
+
+	query := u.Query()
+	query.Del(signatureParam)
+	query.Set(signatureExpiresParam, strconv.FormatInt(now.Add(s.ttl).Unix(), 10))
+	query.Set(signatureNonceParam, hex.EncodeToString(nonce))
+	query.Set(signatureParam, s.mac(u.Path, query))
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// mac returns the signature of the path and of the query without its signature parameter.
+func (s *webhookSigner) mac(path string, query url.Values) string {
+	unsigned := make(url.Values, len(query))
+	for key, values := range query {
+		if key != signatureParam {
+			unsigned[key] = values
+		}
+	}
+
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(path + "?" + unsigned.Encode()))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// verify checks the signature and the expiry of a webhook URL and returns its nonce.
+func (s *webhookSigner) verify(u *url.URL, now time.Time) (nonce string, expires time.Time, err error) {
+	query := u.Query()
+
+	signature, err := hex.DecodeString(query.Get(signatureParam))
+	if err != nil || len(signature) == 0 {
+		return "", time.Time{}, errWebhookSignature
+	}
+
+	expected, _ := hex.DecodeString(s.mac(u.Path, query))
+	if !hmac.Equal(signature, expected) {
+		return "", time.Time{}, errWebhookSignature
+	}
+
+	unix, err := strconv.ParseInt(query.Get(signatureExpiresParam), 10, 64)
+	if err != nil {
+		return "", time.Time{}, errWebhookSignature
+	}
+
+	expires = time.Unix(unix, 0)
+	if now.After(expires) {
+		return "", time.Time{}, errWebhookExpired
+	}
+
+	return query.Get(signatureNonceParam), expires, nil
+}
+
+// nonceCache remembers the nonces of the webhook calls already handled until they expire.
+type nonceCache struct {
+	mu     sync.Mutex
+	nonces map[string]time.Time
+}
+
+// reserve records a nonce, unless it was already used.
+func (nc *nonceCache) reserve(nonce string, expires, now time.Time) bool {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+
+	if nc.nonces == nil {
+		nc.nonces = make(map[string]time.Time)
+	}
+
+	for n, exp := range nc.nonces {
+		if now.After(exp) {
+			delete(nc.nonces, n)
+		}
+	}
+
+	if _, ok := nc.nonces[nonce]; ok {
+		return false
+	}
+
+	nc.nonces[nonce] = expires
+
+	return true
+}
+
+// release forgets a nonce, so that a failed call can be retried.
+func (nc *nonceCache) release(nonce string) {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+
+	delete(nc.nonces, nonce)
+}
@@ -0,0 +1,147 @@
+package gotenberg
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/gotenbergtest"
+)
+
+func TestWebhookSignature(t *testing.T) {
+	key := []byte("secret")
+	signer := newWebhookSigner(key, time.Minute)
+
+	signed, err := signer.sign("https://example.com/hook?job=42", time.Now())
+	require.NoError(t, err)
+
+	fail := true
+	wh := NewWebhookHandler(func(context.Context, WebhookResult) error {
+		if fail {
+			return errors.New("storage unavailable")
+		}
+
+		return nil
+	}, nil)
+	wh.VerifySignature(key)
+
+	call := func(target string) int {
+		w := httptest.NewRecorder()
+		wh.ServeHTTP(w, httptest.NewRequest(http.MethodPost, target, strings.NewReader("%PDF-result")))
+
+		return w.Code
+	}
+
+	// A failed call can be retried, but a successful one cannot be replayed.
+	assert.Equal(t, http.StatusInternalServerError, call(signed))
+	fail = false
+	assert.Equal(t, http.StatusNoContent, call(signed))
+	assert.Equal(t, http.StatusUnauthorized, call(signed))
+
+	assert.Equal(t, http.StatusUnauthorized, call("https://example.com/hook?job=42"))
+	assert.Equal(t, http.StatusUnauthorized, call(strings.Replace(signed, "job=42", "job=43", 1)))
+
+	expired, err := signer.sign("https://example.com/hook", time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, call(expired))
+
+	other, err := newWebhookSigner([]byte("other"), time.Minute).sign("https://example.com/hook", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, call(other))
+}
+
+func TestSubmitSigned(t *testing.T) {
+	key := []byte("secret")
+	c, jobs := newJobsClient(t, newWebhookServer(t, "%PDF-result", false).URL)
+	WithWebhookSigning(key, time.Minute)(c)
+	jobs.VerifySignature(key)
+
+	job, err := c.Submit(context.Background(), newJobRequest(t))
+	require.NoError(t, err)
+
+	res, err := job.Wait(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-result", string(res.Data))
+}
+
+func TestWebhookSignatureEvents(t *testing.T) {
+	key := []byte("secret")
+
+	eventsURL, err := WebhookEventsURL("https://example.com/hook?job=42")
+	require.NoError(t, err)
+
+	signed, err := newWebhookSigner(key, time.Minute).sign(eventsURL, time.Now())
+	require.NoError(t, err)
+
+	var got []WebhookEventType
+	wh := NewWebhookHandler(nil, nil)
+	wh.OnEvent(func(_ context.Context, event WebhookEvent) error {
+		got = append(got, event.Type)
+
+		return nil
+	})
+	wh.VerifySignature(key)
+
+	call := func(eventType string) int {
+		body := `{"type":"` + eventType + `","timestamp":"2024-05-01T10:00:00Z"}`
+		w := httptest.NewRecorder()
+		wh.ServeHTTP(w, httptest.NewRequest(http.MethodPost, signed, strings.NewReader(body)))
+
+		return w.Code
+	}
+
+	// The events of a request share the signed URL, but each of them can only be handled once.
+	assert.Equal(t, http.StatusNoContent, call("started"))
+	assert.Equal(t, http.StatusNoContent, call("completed"))
+	assert.Equal(t, http.StatusUnauthorized, call("completed"))
+	assert.Equal(t, []WebhookEventType{WebhookEventStarted, WebhookEventCompleted}, got)
+}
+
+func TestSendSignedTwice(t *testing.T) {
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(hook.Close)
+
+	srv := gotenbergtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(srv.URL, srv.Client(), WithWebhookSigning([]byte("secret"), time.Minute))
+	require.NoError(t, err)
+
+	req := NewURLRequest("https://example.com")
+	req.UseWebhook(hook.URL+"/result", hook.URL+"/error")
+
+	// Both sends share the request, which must be left untouched.
+	done := make(chan error, 2)
+	for range 2 {
+		go func() {
+			resp, err := c.Send(context.Background(), req)
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+			done <- err
+		}()
+	}
+	require.NoError(t, <-done)
+	require.NoError(t, <-done)
+
+	assert.Equal(t, hook.URL+"/result", req.customHeaders()[headerWebhookURL])
+	assert.NotContains(t, Describe(req).Headers[string(headerWebhookURL)], signatureParam)
+
+	requests := srv.Requests()
+	require.Len(t, requests, 2)
+
+	first := requests[0].Header.Get(string(headerWebhookURL))
+	second := requests[1].Header.Get(string(headerWebhookURL))
+	assert.Contains(t, first, signatureNonceParam)
+	assert.Contains(t, second, signatureNonceParam)
+	assert.NotEqual(t, first, second)
+}
@@ -0,0 +1,706 @@
+package gotenberg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+var errNoDocumentSource = errors.New("document has no path or URL")
+
+// RequestType identifies the kind of request described by a RequestSpec.
+type RequestType string
+
+const (
+	RequestHTML           RequestType = "html"
+	RequestURL            RequestType = "url"
+	RequestMarkdown       RequestType = "markdown"
+	RequestLibreOffice    RequestType = "libreoffice"
+	RequestMerge          RequestType = "merge"
+	RequestSplitIntervals RequestType = "split-intervals"
+	RequestSplitPages     RequestType = "split-pages"
+	RequestReadMetadata   RequestType = "read-metadata"
+	RequestWriteMetadata  RequestType = "write-metadata"
+)
+
+// DocumentSpec references a document by path or by URL.
+type DocumentSpec struct {
+	// Path is a file path. Relative paths are resolved from the directory of the spec file.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// URL is fetched by the client, see document.FromURL.
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+	// Filename overrides the filename sent to Gotenberg, which is otherwise the base name of the path or URL.
+	Filename string `json:"filename,omitempty" yaml:"filename,omitempty"`
+}
+
+// WebhookSpec configures the webhooks of a request.
+type WebhookSpec struct {
+	URL          string            `json:"url" yaml:"url"`
+	ErrorURL     string            `json:"errorUrl" yaml:"errorUrl"`
+	Method       string            `json:"method,omitempty" yaml:"method,omitempty"`
+	ErrorMethod  string            `json:"errorMethod,omitempty" yaml:"errorMethod,omitempty"`
+	EventsURL    string            `json:"eventsUrl,omitempty" yaml:"eventsUrl,omitempty"`
+	EventsMethod string            `json:"eventsMethod,omitempty" yaml:"eventsMethod,omitempty"`
+	ExtraHeaders map[string]string `json:"extraHeaders,omitempty" yaml:"extraHeaders,omitempty"`
+}
+
+// RequestSpec is a serializable description of a request, e.g. to define conversions in configuration
+// files rather than in code.
+//
+// Options are the Gotenberg form fields of the request type, e.g. "landscape" or "nativePageRanges", with
+// scalar values, or objects and arrays for fields which take JSON, e.g. "cookies" or "metadata". Credentials
+// are not part of a spec.
+type RequestSpec struct {
+	Type RequestType `json:"type" yaml:"type"`
+	// Screenshot asks for a screenshot rather than a PDF. Only for html, url and markdown requests.
+	Screenshot bool `json:"screenshot,omitempty" yaml:"screenshot,omitempty"`
+	// URL is the page to convert, for url requests.
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+	// Index is the index.html of html and markdown requests.
+	Index *DocumentSpec `json:"index,omitempty" yaml:"index,omitempty"`
+	// Documents are the markdown files of markdown requests, or the files of other requests.
+	Documents []DocumentSpec `json:"documents,omitempty" yaml:"documents,omitempty"`
+	// Assets, Header and Footer are only for html, url and markdown requests, except Assets for url.
+	Assets []DocumentSpec `json:"assets,omitempty" yaml:"assets,omitempty"`
+	Header *DocumentSpec  `json:"header,omitempty" yaml:"header,omitempty"`
+	Footer *DocumentSpec  `json:"footer,omitempty" yaml:"footer,omitempty"`
+
+	Options map[string]any `json:"options,omitempty" yaml:"options,omitempty"`
+
+	OutputFilename string       `json:"outputFilename,omitempty" yaml:"outputFilename,omitempty"`
+	Trace          string       `json:"trace,omitempty" yaml:"trace,omitempty"`
+	Webhook        *WebhookSpec `json:"webhook,omitempty" yaml:"webhook,omitempty"`
+}
+
+// nolint: gochecknoglobals
+var (
+	commonOptions = []formField{fieldDownloadFrom}
+
+	chromiumOptions = []formField{
+		fieldChromiumWaitDelay, fieldChromiumWaitForExpression, fieldChromiumEmulatedMediaType, fieldChromiumCookies,
+		fieldChromiumUserAgent, fieldChromiumExtraHTTPHeaders, fieldChromiumFailOnHTTPStatusCodes,
+		fieldChromiumFailOnResourceHTTPStatusCodes, fieldChromiumFailOnConsoleExceptions,
+		fieldChromiumFailOnResourceLoadingFailed, fieldChromiumSkipNetworkIdleEvent,
+		fieldChromiumPaperWidth, fieldChromiumPaperHeight, fieldChromiumMarginTop, fieldChromiumMarginBottom,
+		fieldChromiumMarginLeft, fieldChromiumMarginRight, fieldChromiumLandscapeChrome,
+		fieldChromiumNativePageRanges, fieldChromiumScale, fieldChromiumSinglePage, fieldChromiumPreferCSSPageSize,
+		fieldChromiumPrintBackground, fieldChromiumOmitBackground, fieldChromiumGenerateDocumentOutline,
+		fieldOfficePdfA, fieldOfficePdfUa, fieldMetadata,
+		fieldScreenshotWidth, fieldScreenshotHeight, fieldScreenshotClip, fieldScreenshotFormat,
+		fieldScreenshotQuality, fieldScreenshotOptimizeForSpeed,
+	}
+
+	officeOptions = []formField{
+		fieldOfficeLandscape, fieldOfficeNativePageRanges, fieldOfficeExportFormFields,
+		fieldOfficeAllowDuplicateFieldNames, fieldOfficeExportBookmarks, fieldOfficeExportBookmarksToPdfDestination,
+		fieldOfficeExportPlaceholders, fieldOfficeExportNotes, fieldOfficeExportNotesPages,
+		fieldOfficeExportOnlyNotesPages, fieldOfficeExportNotesInMargin, fieldOfficeConvertOooTargetToPdfTarget,
+		fieldOfficeExportLinksRelativeFsys, fieldOfficeExportHiddenSlides, fieldOfficeSkipEmptyPages,
+		fieldOfficeAddOriginalDocumentAsStream, fieldOfficeSinglePageSheets, fieldOfficeLosslessImageCompression,
+		fieldOfficeQuality, fieldOfficeReduceImageResolution, fieldOfficeMaxImageResolution, fieldOfficeMerge,
+		fieldOfficePdfA, fieldOfficePdfUa, fieldMetadata,
+	}
+
+	specOptions = map[RequestType][]formField{
+		RequestHTML:           chromiumOptions,
+		RequestURL:            chromiumOptions,
+		RequestMarkdown:       chromiumOptions,
+		RequestLibreOffice:    officeOptions,
+		RequestMerge:          {fieldMergePdfA, fieldMergePdfUA, fieldMetadata},
+		RequestSplitIntervals: {fieldSplitSpan},
+		RequestSplitPages:     {fieldSplitSpan, fieldSplitUnify},
+		RequestReadMetadata:   {},
+		RequestWriteMetadata:  {fieldMetadata},
+	}
+
+	// jsonOptions take a JSON value, which a spec may write as an object or an array.
+	jsonOptions = map[formField]bool{
+		fieldChromiumCookies:                       true,
+		fieldChromiumExtraHTTPHeaders:              true,
+		fieldChromiumFailOnHTTPStatusCodes:         true,
+		fieldChromiumFailOnResourceHTTPStatusCodes: true,
+		fieldMetadata:                              true,
+		fieldDownloadFrom:                          true,
+	}
+)
+
+// ReadSpec decodes a JSON or YAML spec. Unknown keys are rejected.
+func ReadSpec(r io.Reader) (*RequestSpec, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	var spec RequestSpec
+	if err := dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("decoding spec: %w", err)
+	}
+
+	return &spec, nil
+}
+
+// LoadSpecFile reads a JSON or YAML spec file and builds its request with LoadSpec. Relative document
+// paths are resolved from the directory of the file.
+func LoadSpecFile(ctx context.Context, fpath string) (Requester, *RequestSpec, error) {
+	data, err := os.ReadFile(fpath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading spec %s: %w", fpath, err)
+	}
+
+	spec, err := ReadSpec(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("spec %s: %w", fpath, err)
+	}
+
+	req, err := LoadSpec(ctx, spec, filepath.Dir(fpath))
+	if err != nil {
+		return nil, nil, fmt.Errorf("spec %s: %w", fpath, err)
+	}
+
+	return req, spec, nil
+}
+
+// LoadSpec builds the request described by spec. Relative document paths are resolved from baseDir.
+// Unknown options, documents which do not apply to the request type and values redacted by ToSpec are
+// reported, together with the problems found by the request's Validate, as a *ValidationError.
+func LoadSpec(ctx context.Context, spec *RequestSpec, baseDir string) (Requester, error) {
+	allowed, ok := specOptions[spec.Type]
+	if !ok {
+		return nil, &ValidationError{Errors: []error{&FieldError{
+			Field:  "type",
+			Reason: fmt.Sprintf("unknown request type %q, expected one of %s", spec.Type, specTypeNames()),
+		}}}
+	}
+
+	b := &specBuilder{ctx: ctx, baseDir: baseDir, spec: spec}
+
+	req := b.request()
+	b.options(req, append(allowed, commonOptions...))
+	b.headers(req)
+
+	if len(b.v.errs) > 0 {
+		return nil, b.v.err()
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// specBuilder builds a request from a spec and accumulates the problems found.
+type specBuilder struct {
+	ctx     context.Context
+	baseDir string
+	spec    *RequestSpec
+	v       validator
+}
+
+func (b *specBuilder) request() multipartRequester {
+	spec := b.spec
+	chromium := spec.Type == RequestHTML || spec.Type == RequestURL || spec.Type == RequestMarkdown
+
+	b.forbid(spec.Type != RequestURL && spec.URL != "", "url")
+	b.forbid(spec.Type != RequestHTML && spec.Type != RequestMarkdown && spec.Index != nil, "index")
+	b.forbid(spec.Type != RequestHTML && spec.Type != RequestMarkdown && len(spec.Assets) > 0, "assets")
+	b.forbid(!chromium && spec.Header != nil, "header")
+	b.forbid(!chromium && spec.Footer != nil, "footer")
+	b.forbid(!chromium && spec.Screenshot, "screenshot")
+	b.forbid((spec.Type == RequestHTML || spec.Type == RequestURL) && len(spec.Documents) > 0, "documents")
+
+	docs := b.documents("documents", spec.Documents)
+
+	var req multipartRequester
+
+	switch spec.Type {
+	case RequestHTML:
+		html := NewHTMLRequest(b.document("index", spec.Index))
+		html.Assets(b.documents("assets", spec.Assets)...)
+		b.headerFooter(html.chromiumRequest)
+		req = html
+	case RequestURL:
+		url := NewURLRequest(spec.URL)
+		b.headerFooter(url.chromiumRequest)
+		req = url
+	case RequestMarkdown:
+		markdown := NewMarkdownRequest(b.document("index", spec.Index), docs...)
+		markdown.Assets(b.documents("assets", spec.Assets)...)
+		b.headerFooter(markdown.chromiumRequest)
+		req = markdown
+	case RequestLibreOffice:
+		req = NewLibreOfficeRequest(docs...)
+	case RequestMerge:
+		req = NewMergeRequest(docs...)
+	case RequestSplitIntervals:
+		req = NewSplitIntervalsRequest(docs...)
+	case RequestSplitPages:
+		req = NewSplitPagesRequest(docs...)
+	case RequestReadMetadata:
+		req = NewReadMetadataRequest(docs...)
+	case RequestWriteMetadata:
+		req = NewWriteMetadataRequest(docs...)
+	}
+
+	return req
+}
+
+func (b *specBuilder) forbid(set bool, field string) {
+	if set {
+		b.v.addf(field, "not allowed for %s requests", b.spec.Type)
+	}
+}
+
+func (b *specBuilder) headerFooter(req *chromiumRequest) {
+	if b.spec.Header != nil {
+		req.Header(b.document("header", b.spec.Header))
+	}
+
+	if b.spec.Footer != nil {
+		req.Footer(b.document("footer", b.spec.Footer))
+	}
+}
+
+func (b *specBuilder) documents(field string, specs []DocumentSpec) []document.Document {
+	docs := make([]document.Document, 0, len(specs))
+
+	for i := range specs {
+		if doc := b.document(fmt.Sprintf("%s[%d]", field, i), &specs[i]); doc != nil {
+			docs = append(docs, doc)
+		}
+	}
+
+	return docs
+}
+
+func (b *specBuilder) document(field string, spec *DocumentSpec) document.Document {
+	if spec == nil {
+		return nil
+	}
+
+	if (spec.Path == "") == (spec.URL == "") {
+		b.v.addf(field, "exactly one of path and url is required")
+
+		return nil
+	}
+
+	if spec.URL != "" {
+		doc, err := document.FromURL(b.ctx, spec.URL, &document.URLOptions{Filename: spec.Filename})
+		if err != nil {
+			b.v.addf(field, "%v", err)
+		}
+
+		return doc
+	}
+
+	fpath := spec.Path
+	if !filepath.IsAbs(fpath) {
+		fpath = filepath.Join(b.baseDir, fpath)
+	}
+
+	fname := spec.Filename
+	if fname == "" {
+		fname = filepath.Base(fpath)
+	}
+
+	if _, err := os.Stat(fpath); err != nil {
+		b.v.addf(field, "%v", err)
+
+		return nil
+	}
+
+	doc, err := document.FromPath(fname, fpath)
+	if err != nil {
+		b.v.addf(field, "%v", err)
+	}
+
+	return doc
+}
+
+func (b *specBuilder) options(req multipartRequester, allowed []formField) {
+	known := make(map[string]bool, len(allowed))
+	for _, field := range allowed {
+		known[string(field)] = true
+	}
+
+	fields := req.formFields()
+
+	for name, value := range b.spec.Options {
+		field := "options." + name
+
+		if !known[name] {
+			if suggestion := closestOption(name, allowed); suggestion != "" {
+				b.v.addf(field, "unknown option for %s requests, did you mean %q?", b.spec.Type, suggestion)
+			} else {
+				b.v.addf(field, "unknown option for %s requests", b.spec.Type)
+			}
+
+			continue
+		}
+
+		raw, err := optionString(formField(name), value)
+		if err != nil {
+			b.v.addf(field, "%v", err)
+
+			continue
+		}
+
+		if isRedacted(formField(name), raw) {
+			b.v.addf(field, "holds a redacted value, replace it before loading the spec")
+
+			continue
+		}
+
+		fields[formField(name)] = raw
+	}
+}
+
+// isRedacted reports whether a form field value holds a value redacted by ToSpec.
+func isRedacted(field formField, raw string) bool {
+	if !jsonOptions[field] {
+		return raw == Redacted
+	}
+
+	var decoded any
+	if err := json.Unmarshal([]byte(raw), &decoded); err != nil {
+		return false
+	}
+
+	return hasRedacted(decoded)
+}
+
+func hasRedacted(v any) bool {
+	switch v := v.(type) {
+	case string:
+		return v == Redacted
+	case map[string]any:
+		for _, value := range v {
+			if hasRedacted(value) {
+				return true
+			}
+		}
+	case []any:
+		for _, value := range v {
+			if hasRedacted(value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// optionString converts an option to the value of its form field.
+func optionString(field formField, value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case map[string]any, []any:
+		if !jsonOptions[field] {
+			return "", errors.New("must be a string, a number or a boolean")
+		}
+
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("marshal to JSON: %w", err)
+		}
+
+		return string(data), nil
+	default:
+		return "", fmt.Errorf("unsupported value %v", value)
+	}
+}
+
+func (b *specBuilder) headers(req multipartRequester) {
+	headers := req.customHeaders()
+
+	if b.spec.OutputFilename != "" {
+		headers[headerOutputFilename] = b.spec.OutputFilename
+	}
+
+	if b.spec.Trace != "" {
+		headers[headerTrace] = b.spec.Trace
+	}
+
+	hook := b.spec.Webhook
+	if hook == nil {
+		return
+	}
+
+	for name, value := range map[string]string{"url": hook.URL, "errorUrl": hook.ErrorURL, "eventsUrl": hook.EventsURL} {
+		if value == Redacted {
+			b.v.addf("webhook."+name, "holds a redacted value, replace it before loading the spec")
+		}
+	}
+
+	for name, value := range hook.ExtraHeaders {
+		if value == Redacted {
+			b.v.addf("webhook.extraHeaders."+name, "holds a redacted value, replace it before loading the spec")
+		}
+	}
+
+	headers[headerWebhookURL] = hook.URL
+	headers[headerWebhookErrorURL] = hook.ErrorURL
+
+	if hook.Method != "" {
+		headers[headerWebhookMethod] = ensureWebhookMethod(hook.Method)
+	}
+
+	if hook.ErrorMethod != "" {
+		headers[headerWebhookErrorMethod] = ensureWebhookMethod(hook.ErrorMethod)
+	}
+
+	if hook.EventsURL != "" {
+		headers[headerWebhookEventsURL] = hook.EventsURL
+	}
+
+	if hook.EventsMethod != "" {
+		headers[headerWebhookEventsMethod] = ensureWebhookMethod(hook.EventsMethod)
+	}
+
+	if len(hook.ExtraHeaders) > 0 {
+		extra, err := json.Marshal(hook.ExtraHeaders)
+		if err != nil {
+			b.v.addf("webhook.extraHeaders", "%v", err)
+
+			return
+		}
+
+		headers[headerWebhookExtraHeaders] = string(extra)
+	}
+}
+
+// ToSpec returns the spec of a request, e.g. to save it in a configuration file. Every document must
+// have been created with document.FromPath or document.FromURL. Credentials set with UseBasicAuth and
+// the password of LibreOffice requests are left out, the values of cookies, extra HTTP headers and webhook
+// extra headers are redacted as by Describe, and webhook URLs are stripped of their signature. LoadSpec
+// rejects redacted values, so they must be replaced before the spec is loaded.
+func ToSpec(req multipartRequester) (*RequestSpec, error) {
+	spec := &RequestSpec{Options: make(map[string]any)}
+
+	var (
+		docs     []document.Document
+		chromium *chromiumRequest
+		errs     []error
+	)
+
+	toSpec := func(field string, doc document.Document) *DocumentSpec {
+		ds, err := documentSpec(doc)
+		if err != nil {
+			errs = append(errs, &FieldError{Field: field, Reason: err.Error()})
+		}
+
+		return ds
+	}
+
+	switch r := req.(type) {
+	case *HTMLRequest:
+		spec.Type, chromium = RequestHTML, r.chromiumRequest
+		spec.Index = toSpec("index", r.index)
+		spec.Assets = documentSpecs("assets", r.assets, toSpec)
+	case *URLRequest:
+		spec.Type, chromium = RequestURL, r.chromiumRequest
+		spec.URL = r.fields[fieldURL]
+	case *MarkdownRequest:
+		spec.Type, chromium = RequestMarkdown, r.chromiumRequest
+		spec.Index = toSpec("index", r.index)
+		spec.Assets = documentSpecs("assets", r.assets, toSpec)
+		docs = r.markdowns
+	case *LibreOfficeRequest:
+		spec.Type, docs = RequestLibreOffice, r.docs
+	case *MergeRequest:
+		spec.Type, docs = RequestMerge, r.pdfs
+	case *SplitIntervalsRequest:
+		spec.Type, docs = RequestSplitIntervals, r.pdfs
+	case *SplitPagesRequest:
+		spec.Type, docs = RequestSplitPages, r.pdfs
+	case *ReadMetadataRequest:
+		spec.Type, docs = RequestReadMetadata, r.pdfs
+	case *WriteMetadataRequest:
+		spec.Type, docs = RequestWriteMetadata, r.pdfs
+	default:
+		return nil, fmt.Errorf("unsupported request type %T", req)
+	}
+
+	spec.Documents = documentSpecs("documents", docs, toSpec)
+
+	if chromium != nil {
+		if chromium.header != nil {
+			spec.Header = toSpec("header", chromium.header)
+		}
+
+		if chromium.footer != nil {
+			spec.Footer = toSpec("footer", chromium.footer)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, &ValidationError{Errors: errs}
+	}
+
+	for name, value := range req.formFields() {
+		// The URL and the split mode are part of the spec itself, and the password is a credential.
+		if name == fieldURL || name == fieldSplitMode || name == fieldOfficePassword {
+			continue
+		}
+
+		value = redactField(name, value)
+
+		var decoded any
+		if jsonOptions[name] && json.Unmarshal([]byte(value), &decoded) == nil {
+			spec.Options[string(name)] = decoded
+		} else {
+			spec.Options[string(name)] = value
+		}
+	}
+
+	if len(spec.Options) == 0 {
+		spec.Options = nil
+	}
+
+	specHeaders(spec, req.customHeaders())
+
+	return spec, nil
+}
+
+func specHeaders(spec *RequestSpec, headers map[httpHeader]string) {
+	spec.OutputFilename = headers[headerOutputFilename]
+	spec.Trace = headers[headerTrace]
+
+	if !hasWebhookHeaders(headers) {
+		return
+	}
+
+	spec.Webhook = &WebhookSpec{
+		URL:          stripSignature(headers[headerWebhookURL]),
+		ErrorURL:     stripSignature(headers[headerWebhookErrorURL]),
+		Method:       headers[headerWebhookMethod],
+		ErrorMethod:  headers[headerWebhookErrorMethod],
+		EventsURL:    stripSignature(headers[headerWebhookEventsURL]),
+		EventsMethod: headers[headerWebhookEventsMethod],
+	}
+
+	if raw := headers[headerWebhookExtraHeaders]; raw != "" {
+		_ = json.Unmarshal([]byte(raw), &spec.Webhook.ExtraHeaders)
+
+		for name := range spec.Webhook.ExtraHeaders {
+			spec.Webhook.ExtraHeaders[name] = Redacted
+		}
+	}
+}
+
+func hasWebhookHeaders(headers map[httpHeader]string) bool {
+	for _, header := range []httpHeader{headerWebhookURL, headerWebhookErrorURL, headerWebhookEventsURL} {
+		if headers[header] != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func documentSpecs(
+	field string,
+	docs []document.Document,
+	toSpec func(field string, doc document.Document) *DocumentSpec,
+) []DocumentSpec {
+	specs := make([]DocumentSpec, 0, len(docs))
+
+	for i, doc := range docs {
+		if ds := toSpec(fmt.Sprintf("%s[%d]", field, i), doc); ds != nil {
+			specs = append(specs, *ds)
+		}
+	}
+
+	if len(specs) == 0 {
+		return nil
+	}
+
+	return specs
+}
+
+func documentSpec(doc document.Document) (*DocumentSpec, error) {
+	if doc == nil {
+		return nil, nil //nolint:nilnil // a missing document has no spec
+	}
+
+	source, ok := document.SourceOf(doc)
+	if !ok || (source.Path == "" && source.URL == "") {
+		return nil, fmt.Errorf("%w: %s", errNoDocumentSource, doc.Filename())
+	}
+
+	// Remote filenames may change once fetched, so only the one imposed on the document is kept.
+	ds := &DocumentSpec{Path: source.Path, URL: source.URL, Filename: source.Filename}
+
+	if source.Path != "" && doc.Filename() != filepath.Base(source.Path) {
+		ds.Filename = doc.Filename()
+	}
+
+	return ds, nil
+}
+
+func specTypeNames() string {
+	names := make([]string, 0, len(specOptions))
+	for name := range specOptions {
+		names = append(names, strconv.Quote(string(name)))
+	}
+
+	sort.Strings(names)
+
+	return fmt.Sprint(names)
+}
+
+// closestOption returns the allowed option closest to name, if it is close enough to be a typo.
+func closestOption(name string, allowed []formField) string {
+	best, bestDistance := "", len(name)/2+1
+
+	for _, field := range allowed {
+		if d := editDistance(name, string(field)); d < bestDistance {
+			best, bestDistance = string(field), d
+		}
+	}
+
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,189 @@
+package gotenberg
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+	"github.com/starwalkn/gotenberg-go-client/v8/test"
+)
+
+func TestLoadSpecFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.html"), []byte("<html>Hello</html>"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "style.css"), []byte("body {}"), 0o600))
+
+	fpath := filepath.Join(dir, "job.yaml")
+	require.NoError(t, os.WriteFile(fpath, []byte(`
+type: html
+index:
+  path: index.html
+assets:
+  - path: style.css
+options:
+  landscape: true
+  scale: 0.9
+  nativePageRanges: 1-3
+  extraHttpHeaders:
+    X-Tenant: acme
+outputFilename: report
+trace: nightly
+`), 0o600))
+
+	req, spec, err := LoadSpecFile(context.Background(), fpath)
+	require.NoError(t, err)
+	assert.Equal(t, RequestHTML, spec.Type)
+	require.IsType(t, &HTMLRequest{}, req)
+
+	desc := Describe(req)
+	assert.Equal(t, endpointHTMLConvert, desc.Endpoint)
+	assert.Equal(t, map[string]string{
+		"landscape":        "true",
+		"scale":            "0.9",
+		"nativePageRanges": "1-3",
+		"extraHttpHeaders": `{"X-Tenant":"REDACTED"}`,
+	}, desc.Fields)
+	assert.Equal(t, map[string]string{"Gotenberg-Output-Filename": "report", "Gotenberg-Trace": "nightly"}, desc.Headers)
+	require.Len(t, desc.Documents, 2)
+	assert.Equal(t, "style.css", desc.Documents[1].Filename)
+}
+
+func TestLoadSpecErrors(t *testing.T) {
+	_, err := ReadSpec(strings.NewReader(`{"type": "merge", "documentz": []}`))
+	require.ErrorContains(t, err, "documentz")
+
+	spec, err := ReadSpec(strings.NewReader(`{
+		"type": "merge",
+		"url": "https://example.com",
+		"documents": [{"path": "missing.pdf"}],
+		"options": {"pdfaa": "PDF/A-1b", "landscape": true}
+	}`))
+	require.NoError(t, err)
+
+	_, err = LoadSpec(context.Background(), spec, t.TempDir())
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.ErrorContains(t, err, `options.pdfaa: unknown option for merge requests, did you mean "pdfa"?`)
+	assert.ErrorContains(t, err, "options.landscape: unknown option for merge requests")
+	assert.ErrorContains(t, err, "url: not allowed for merge requests")
+	assert.ErrorContains(t, err, "documents[0]")
+
+	_, err = LoadSpec(context.Background(), &RequestSpec{Type: "pdf"}, "")
+	require.ErrorContains(t, err, `unknown request type "pdf"`)
+}
+
+func TestToSpec(t *testing.T) {
+	pdf, err := document.FromPath("report.pdf", test.PDFTestFilePath(t, "gotenberg.pdf"))
+	require.NoError(t, err)
+
+	split := NewSplitPagesRequest(pdf)
+	split.SplitSpan("1-2")
+	split.SplitUnify(true)
+	split.Trace("split")
+	split.UseBasicAuth("foo", "secret")
+	require.NoError(t, split.SetWebhookExtraHeaders(map[string]string{"X-Job": "42"}))
+	split.UseWebhook("https://example.com/hook", "https://example.com/error")
+
+	spec, err := ToSpec(split)
+	require.NoError(t, err)
+	assert.Equal(t, RequestSplitPages, spec.Type)
+	assert.Equal(t, []DocumentSpec{{Path: test.PDFTestFilePath(t, "gotenberg.pdf"), Filename: "report.pdf"}}, spec.Documents)
+	assert.Equal(t, map[string]any{"splitSpan": "1-2", "splitUnify": "true"}, spec.Options)
+	assert.Equal(t, map[string]string{"X-Job": Redacted}, spec.Webhook.ExtraHeaders)
+
+	data, err := json.Marshal(spec)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret")
+
+	decoded, err := ReadSpec(strings.NewReader(string(data)))
+	require.NoError(t, err)
+
+	_, err = LoadSpec(context.Background(), decoded, "")
+	require.ErrorContains(t, err, "webhook.extraHeaders.X-Job: holds a redacted value")
+
+	decoded.Webhook.ExtraHeaders["X-Job"] = "42"
+
+	req, err := LoadSpec(context.Background(), decoded, "")
+	require.NoError(t, err)
+
+	want, got := Describe(split), Describe(req)
+	delete(want.Headers, string(headerAuthorization))
+	assert.Equal(t, want, got)
+
+	docx, err := document.FromPath("document.docx", test.LibreOfficeTestFilePath(t, "document.docx"))
+	require.NoError(t, err)
+
+	office := NewLibreOfficeRequest(docx)
+	office.Password("hunter2")
+	office.Landscape()
+
+	spec, err = ToSpec(office)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"landscape": "true"}, spec.Options)
+
+	data, err = json.Marshal(spec)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "hunter2")
+	assert.NotContains(t, string(data), string(fieldOfficePassword))
+
+	page := NewURLRequest("https://example.com")
+	require.NoError(t, page.Cookies([]Cookie{{Name: "session", Value: "hunter2", Domain: "example.com"}}))
+
+	spec, err = ToSpec(page)
+	require.NoError(t, err)
+
+	data, err = json.Marshal(spec)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "hunter2")
+	assert.Contains(t, string(data), Redacted)
+
+	_, err = LoadSpec(context.Background(), spec, "")
+	require.ErrorContains(t, err, "options.cookies: holds a redacted value")
+
+	require.NoError(t, page.ExtraHTTPHeaders(map[string]string{"X-Api-Key": "hunter2"}))
+	page.UseWebhook("https://example.com/hook?job=1", "https://example.com/error")
+
+	c, err := NewClient("http://localhost", nil, WithWebhookSigning([]byte("key"), 0))
+	require.NoError(t, err)
+
+	httpReq, _, err := c.createRequest(context.Background(), page, page.endpoint())
+	require.NoError(t, err)
+	_ = httpReq.Body.Close()
+	require.Contains(t, httpReq.Header.Get(string(headerWebhookURL)), signatureParam)
+
+	spec, err = ToSpec(page)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/hook?job=1", spec.Webhook.URL)
+	assert.Equal(t, "https://example.com/error", spec.Webhook.ErrorURL)
+
+	data, err = json.Marshal(spec)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "hunter2")
+	assert.NotContains(t, string(data), "gotenberg-")
+
+	named, err := document.FromURL(context.Background(), "https://example.com/files/1", &document.URLOptions{Filename: "annex.pdf"})
+	require.NoError(t, err)
+	unnamed, err := document.FromURL(context.Background(), "https://example.com/files/2", nil)
+	require.NoError(t, err)
+
+	spec, err = ToSpec(NewMergeRequest(named, document.Rename(unnamed, "cover.pdf")))
+	require.NoError(t, err)
+	assert.Equal(t, []DocumentSpec{
+		{URL: "https://example.com/files/1", Filename: "annex.pdf"},
+		{URL: "https://example.com/files/2", Filename: "cover.pdf"},
+	}, spec.Documents)
+
+	inMemory, err := document.FromString("index.html", "<html></html>")
+	require.NoError(t, err)
+
+	_, err = ToSpec(NewHTMLRequest(inMemory))
+	require.ErrorContains(t, err, "index: document has no path or URL")
+}
@@ -40,3 +40,19 @@ func (req *SplitIntervalsRequest) formDocuments() map[string]document.Document {
 func (req *SplitIntervalsRequest) SplitSpan(span int) {
 	req.fields[fieldSplitSpan] = strconv.Itoa(span)
 }
+
+// Validate checks the request options before it is sent.
+func (req *SplitIntervalsRequest) Validate() error {
+	v := &validator{}
+	v.documents("files", req.pdfs, req.requiredDocuments(1), ".pdf")
+	v.validateBase(req.baseRequest)
+
+	raw, ok := req.fields[fieldSplitSpan]
+	if !ok {
+		v.addf(string(fieldSplitSpan), "split span is required")
+	} else if span, err := strconv.Atoi(raw); err != nil || span < 1 {
+		v.addf(string(fieldSplitSpan), "must be a positive number of pages, got %s", raw)
+	}
+
+	return v.err()
+}
@@ -41,6 +41,28 @@ func (req *SplitPagesRequest) SplitSpan(span string) {
 	req.fields[fieldSplitSpan] = span
 }
 
+// SplitSpanOf sets the page ranges to extract, e.g., SplitSpanOf(Range(1, 2), Open(5)). Valid ranges
+// are normalised, invalid ones are reported by Validate.
+func (req *SplitPagesRequest) SplitSpanOf(ranges ...PageRange) {
+	req.fields[fieldSplitSpan] = pageRangesField(ranges)
+}
+
 func (req *SplitPagesRequest) SplitUnify(val bool) {
 	req.fields[fieldSplitUnify] = strconv.FormatBool(val)
 }
+
+// Validate checks the request options before it is sent.
+func (req *SplitPagesRequest) Validate() error {
+	v := &validator{}
+	v.documents("files", req.pdfs, req.requiredDocuments(1), ".pdf")
+	v.validateBase(req.baseRequest)
+
+	raw, ok := req.fields[fieldSplitSpan]
+	if !ok || raw == "" {
+		v.addf(string(fieldSplitSpan), "split span is required")
+	} else {
+		v.pageRanges(string(fieldSplitSpan), raw)
+	}
+
+	return v.err()
+}
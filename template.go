@@ -0,0 +1,217 @@
+package gotenberg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+var errMissingAssets = errors.New("missing assets")
+
+// TemplateExecutor is implemented by both *html/template.Template and *text/template.Template.
+type TemplateExecutor interface {
+	Execute(w io.Writer, data any) error
+}
+
+// NewHTMLRequestFromTemplate executes tmpl with data and uses the result as the index of an HTMLRequest.
+//
+// The local files referenced by the result (img src, script src, link href, CSS url() and @import, including
+// those in referenced stylesheets) are read from fsys and added as assets. Paths are resolved from the root
+// of fsys and rewritten to the flat filenames Gotenberg requires. It fails if any asset is missing.
+func NewHTMLRequestFromTemplate(fsys fs.FS, tmpl TemplateExecutor, data any) (*HTMLRequest, error) {
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return nil, fmt.Errorf("executing template: %w", err)
+	}
+
+	c := newAssetCollector(fsys)
+
+	content := c.rewriteHTML(rendered.String())
+	if err := c.collect(); err != nil {
+		return nil, err
+	}
+
+	index, err := document.FromString("index.html", content)
+	if err != nil {
+		return nil, err
+	}
+
+	req := NewHTMLRequest(index)
+	req.Assets(c.assets...)
+
+	return req, nil
+}
+
+// NewHTMLRequestFromTemplateFS parses the files of fsys matching the patterns with html/template,
+// so that the first one can include the others as partials, and calls NewHTMLRequestFromTemplate.
+func NewHTMLRequestFromTemplateFS(fsys fs.FS, data any, patterns ...string) (*HTMLRequest, error) {
+	tmpl, err := htmltemplate.ParseFS(fsys, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("parsing templates: %w", err)
+	}
+
+	return NewHTMLRequestFromTemplate(fsys, tmpl, data)
+}
+
+// nolint: gochecknoglobals
+var (
+	assetTagRe  = regexp.MustCompile(`(?is)<(?:img|script|link|source|image|use|video|audio|embed)\b[^>]*>`)
+	assetAttrRe = regexp.MustCompile(`(?i)(\s(?:src|href|xlink:href|poster)\s*=\s*)("[^"]*"|'[^']*'|[^\s>"']+)`)
+	cssURLRe    = regexp.MustCompile(`(?i)(url\(\s*)("[^"]*"|'[^']*'|[^)\s]+)(\s*\))`)
+	cssImportRe = regexp.MustCompile(`(?i)(@import\s+)("[^"]*"|'[^']*')`)
+	styleAttrRe = regexp.MustCompile(`(?is)(\sstyle\s*=\s*)("[^"]*"|'[^']*')`)
+	styleElemRe = regexp.MustCompile(`(?is)(<style\b[^>]*>)(.*?)(</style>)`)
+	urlSchemeRe = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+)
+
+// assetCollector finds the local files referenced by HTML and CSS and flattens their paths.
+type assetCollector struct {
+	fsys fs.FS
+
+	// flat maps the path of each referenced file to its flat filename.
+	flat map[string]string
+	// owners maps each flat filename back to its path, to detect collisions.
+	owners  map[string]string
+	pending []string
+	missing []string
+	errs    []error
+	assets  []document.Document
+}
+
+func newAssetCollector(fsys fs.FS) *assetCollector {
+	return &assetCollector{
+		fsys:   fsys,
+		flat:   make(map[string]string),
+		owners: make(map[string]string),
+	}
+}
+
+// rewriteHTML registers the assets referenced by content and returns it with flattened references.
+func (c *assetCollector) rewriteHTML(content string) string {
+	content = assetTagRe.ReplaceAllStringFunc(content, func(tag string) string {
+		return replaceQuoted(assetAttrRe, tag, func(ref string) string {
+			return c.reference(".", ref)
+		})
+	})
+
+	content = styleElemRe.ReplaceAllStringFunc(content, func(elem string) string {
+		m := styleElemRe.FindStringSubmatch(elem)
+
+		return m[1] + c.rewriteCSS(".", m[2]) + m[3]
+	})
+
+	return styleAttrRe.ReplaceAllStringFunc(content, func(attr string) string {
+		m := styleAttrRe.FindStringSubmatch(attr)
+		quote, value := m[2][:1], m[2][1:len(m[2])-1]
+
+		return m[1] + quote + c.rewriteCSS(".", value) + quote
+	})
+}
+
+// rewriteCSS registers the assets referenced by a stylesheet located in dir.
+func (c *assetCollector) rewriteCSS(dir, content string) string {
+	rewrite := func(ref string) string {
+		return c.reference(dir, ref)
+	}
+
+	content = replaceQuoted(cssURLRe, content, rewrite)
+
+	return replaceQuoted(cssImportRe, content, rewrite)
+}
+
+// replaceQuoted rewrites the second group of each match of re, keeping its quotes.
+func replaceQuoted(re *regexp.Regexp, s string, rewrite func(ref string) string) string {
+	return re.ReplaceAllStringFunc(s, func(match string) string {
+		m := re.FindStringSubmatch(match)
+
+		value, quote := m[2], ""
+		if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, `'`) {
+			quote, value = value[:1], value[1:len(value)-1]
+		}
+
+		return m[1] + quote + rewrite(value) + quote + strings.Join(m[3:], "")
+	})
+}
+
+// reference registers a reference made from dir and returns the flat filename to use instead.
+// References to remote resources, data URIs and fragments are returned unchanged.
+func (c *assetCollector) reference(dir, ref string) string {
+	trimmed := strings.TrimSpace(ref)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "//") ||
+		urlSchemeRe.MatchString(trimmed) {
+		return ref
+	}
+
+	// Query strings and fragments are not part of the filename.
+	fpath, suffix := trimmed, ""
+	if i := strings.IndexAny(fpath, "?#"); i >= 0 {
+		fpath, suffix = fpath[:i], fpath[i:]
+	}
+
+	fpath = path.Clean(path.Join(dir, strings.TrimPrefix(fpath, "/")))
+
+	if flat, ok := c.flat[fpath]; ok {
+		return flat + suffix
+	}
+
+	flat := path.Base(fpath)
+	if owner, ok := c.owners[flat]; ok {
+		c.errs = append(c.errs, fmt.Errorf("assets %s and %s have the same filename %s", owner, fpath, flat))
+	}
+
+	c.flat[fpath] = flat
+	c.owners[flat] = fpath
+	c.pending = append(c.pending, fpath)
+
+	return flat + suffix
+}
+
+// collect reads every registered asset, following references made by stylesheets.
+func (c *assetCollector) collect() error {
+	for len(c.pending) > 0 {
+		fpath := c.pending[0]
+		c.pending = c.pending[1:]
+
+		doc, err := c.document(fpath)
+		if errors.Is(err, fs.ErrNotExist) {
+			c.missing = append(c.missing, fpath)
+
+			continue
+		}
+		if err != nil {
+			c.errs = append(c.errs, err)
+
+			continue
+		}
+
+		c.assets = append(c.assets, doc)
+	}
+
+	if len(c.missing) > 0 {
+		c.errs = append(c.errs, fmt.Errorf("%w: %s", errMissingAssets, strings.Join(c.missing, ", ")))
+	}
+
+	return errors.Join(c.errs...)
+}
+
+// document returns the asset at fpath. Stylesheets are read eagerly to register and flatten their own references.
+func (c *assetCollector) document(fpath string) (document.Document, error) {
+	if !strings.EqualFold(path.Ext(fpath), ".css") {
+		return document.FromFS(c.fsys, fpath)
+	}
+
+	data, err := fs.ReadFile(c.fsys, fpath)
+	if err != nil {
+		return nil, fmt.Errorf("reading asset %s: %w", fpath, err)
+	}
+
+	return document.FromString(c.flat[fpath], c.rewriteCSS(path.Dir(fpath), string(data)))
+}
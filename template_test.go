@@ -0,0 +1,85 @@
+package gotenberg
+
+import (
+	htmltemplate "html/template"
+	"io"
+	"testing"
+	"testing/fstest"
+	texttemplate "text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func readDocument(t *testing.T, doc document.Document) string {
+	t.Helper()
+
+	r, err := doc.Reader()
+	require.NoError(t, err)
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return string(content)
+}
+
+func TestNewHTMLRequestFromTemplateFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"invoice.html": {Data: []byte(`<html><head><link rel="stylesheet" href="css/style.css"></head>` +
+			`<body>{{ template "items" . }}<img src="img/logo.png?v=1"><a href="https://example.com">x</a>` +
+			`<div style="background: url('img/bg.png')"></div><img src="data:image/png;base64,AAAA"></body></html>`)},
+		"partials/items.html": {Data: []byte(`{{ define "items" }}<p>{{ .Customer }}</p>{{ end }}`)},
+		"css/style.css":       {Data: []byte(`@font-face { src: url("../fonts/font.woff"); }`)},
+		"fonts/font.woff":     {Data: []byte("woff")},
+		"img/logo.png":        {Data: []byte("png")},
+		"img/bg.png":          {Data: []byte("png")},
+	}
+
+	req, err := NewHTMLRequestFromTemplateFS(fsys, map[string]string{"Customer": "<ACME>"},
+		"invoice.html", "partials/*.html")
+	require.NoError(t, err)
+
+	index := readDocument(t, req.index)
+	assert.Contains(t, index, "<p>&lt;ACME&gt;</p>")
+	assert.Contains(t, index, `href="style.css"`)
+	assert.Contains(t, index, `src="logo.png?v=1"`)
+	assert.Contains(t, index, `url('bg.png')`)
+	assert.Contains(t, index, `href="https://example.com"`)
+
+	assets := make(map[string]string)
+	for _, asset := range req.assets {
+		assets[asset.Filename()] = readDocument(t, asset)
+	}
+
+	assert.Len(t, assets, 4)
+	assert.Equal(t, `@font-face { src: url("font.woff"); }`, assets["style.css"])
+	assert.Contains(t, assets, "font.woff")
+	assert.Contains(t, assets, "logo.png")
+	assert.Contains(t, assets, "bg.png")
+}
+
+func TestNewHTMLRequestFromTextTemplate(t *testing.T) {
+	tmpl := texttemplate.Must(texttemplate.New("index").Parse(`<img src="{{ .Logo }}">`))
+
+	req, err := NewHTMLRequestFromTemplate(fstest.MapFS{"logo.png": {Data: []byte("png")}}, tmpl,
+		map[string]string{"Logo": "logo.png"})
+	require.NoError(t, err)
+	require.Len(t, req.assets, 1)
+	assert.Equal(t, "logo.png", req.assets[0].Filename())
+}
+
+func TestNewHTMLRequestFromTemplateErrors(t *testing.T) {
+	tmpl := htmltemplate.Must(htmltemplate.New("index").Parse(`<img src="a/logo.png"><img src="b/logo.png">` +
+		`<link href="missing.css">`))
+
+	_, err := NewHTMLRequestFromTemplate(fstest.MapFS{
+		"a/logo.png": {Data: []byte("png")},
+		"b/logo.png": {Data: []byte("png")},
+	}, tmpl, nil)
+	require.ErrorIs(t, err, errMissingAssets)
+	assert.ErrorContains(t, err, "missing.css")
+	assert.ErrorContains(t, err, "same filename logo.png")
+}
@@ -39,6 +39,11 @@ func PDFTestFilePath(t *testing.T, filename string) string {
 	return abs(t, "pdf", filename)
 }
 
+// GoldenTestFilePath returns the absolute file path of a file in "golden" folder in test/testdata.
+func GoldenTestFilePath(t *testing.T, filename string) string {
+	return abs(t, "golden", filename)
+}
+
 func abs(t *testing.T, kind, filename string) string {
 	_, gofilename, _, ok := runtime.Caller(0)
 	require.True(t, ok, "got no caller information")
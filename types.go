@@ -11,6 +11,7 @@ const (
 	PC SizeUnit = "pc" // Picas.
 )
 
+// PaperDimensions is a paper size. The sizes of the catalogue, such as A4, are in inches.
 type PaperDimensions struct {
 	Width  float64
 	Height float64
@@ -79,10 +80,268 @@ var (
 		Width:  11,
 		Unit:   IN,
 	}
-	// Ledger paper size.
+	// Ledger paper size, i.e. Tabloid in landscape orientation.
 	Ledger = PaperDimensions{
-		Height: 17,
-		Width:  11,
+		Height: 11,
+		Width:  17,
+		Unit:   IN,
+	}
+	// A7 paper size.
+	A7 = PaperDimensions{
+		Height: 4.13,
+		Width:  2.91,
+		Unit:   IN,
+	}
+	// A8 paper size.
+	A8 = PaperDimensions{
+		Height: 2.91,
+		Width:  2.05,
+		Unit:   IN,
+	}
+	// A9 paper size.
+	A9 = PaperDimensions{
+		Height: 2.05,
+		Width:  1.46,
+		Unit:   IN,
+	}
+	// A10 paper size.
+	A10 = PaperDimensions{
+		Height: 1.46,
+		Width:  1.02,
+		Unit:   IN,
+	}
+	// B0 paper size.
+	B0 = PaperDimensions{
+		Height: 55.67,
+		Width:  39.37,
+		Unit:   IN,
+	}
+	// B1 paper size.
+	B1 = PaperDimensions{
+		Height: 39.37,
+		Width:  27.83,
+		Unit:   IN,
+	}
+	// B2 paper size.
+	B2 = PaperDimensions{
+		Height: 27.83,
+		Width:  19.69,
+		Unit:   IN,
+	}
+	// B3 paper size.
+	B3 = PaperDimensions{
+		Height: 19.69,
+		Width:  13.9,
+		Unit:   IN,
+	}
+	// B4 paper size.
+	B4 = PaperDimensions{
+		Height: 13.9,
+		Width:  9.84,
+		Unit:   IN,
+	}
+	// B5 paper size.
+	B5 = PaperDimensions{
+		Height: 9.84,
+		Width:  6.93,
+		Unit:   IN,
+	}
+	// B6 paper size.
+	B6 = PaperDimensions{
+		Height: 6.93,
+		Width:  4.92,
+		Unit:   IN,
+	}
+	// B7 paper size.
+	B7 = PaperDimensions{
+		Height: 4.92,
+		Width:  3.46,
+		Unit:   IN,
+	}
+	// B8 paper size.
+	B8 = PaperDimensions{
+		Height: 3.46,
+		Width:  2.44,
+		Unit:   IN,
+	}
+	// B9 paper size.
+	B9 = PaperDimensions{
+		Height: 2.44,
+		Width:  1.73,
+		Unit:   IN,
+	}
+	// B10 paper size.
+	B10 = PaperDimensions{
+		Height: 1.73,
+		Width:  1.22,
+		Unit:   IN,
+	}
+	// C0 envelope size.
+	C0 = PaperDimensions{
+		Height: 51.06,
+		Width:  36.1,
+		Unit:   IN,
+	}
+	// C1 envelope size.
+	C1 = PaperDimensions{
+		Height: 36.1,
+		Width:  25.51,
+		Unit:   IN,
+	}
+	// C2 envelope size.
+	C2 = PaperDimensions{
+		Height: 25.51,
+		Width:  18.03,
+		Unit:   IN,
+	}
+	// C3 envelope size.
+	C3 = PaperDimensions{
+		Height: 18.03,
+		Width:  12.76,
+		Unit:   IN,
+	}
+	// C4 envelope size.
+	C4 = PaperDimensions{
+		Height: 12.76,
+		Width:  9.02,
+		Unit:   IN,
+	}
+	// C5 envelope size.
+	C5 = PaperDimensions{
+		Height: 9.02,
+		Width:  6.38,
+		Unit:   IN,
+	}
+	// C6 envelope size.
+	C6 = PaperDimensions{
+		Height: 6.38,
+		Width:  4.49,
+		Unit:   IN,
+	}
+	// C7 envelope size.
+	C7 = PaperDimensions{
+		Height: 4.49,
+		Width:  3.19,
+		Unit:   IN,
+	}
+	// C8 envelope size.
+	C8 = PaperDimensions{
+		Height: 3.19,
+		Width:  2.24,
+		Unit:   IN,
+	}
+	// C9 envelope size.
+	C9 = PaperDimensions{
+		Height: 2.24,
+		Width:  1.57,
+		Unit:   IN,
+	}
+	// C10 envelope size.
+	C10 = PaperDimensions{
+		Height: 1.57,
+		Width:  1.1,
+		Unit:   IN,
+	}
+	// JISB0 paper size.
+	JISB0 = PaperDimensions{
+		Height: 57.32,
+		Width:  40.55,
+		Unit:   IN,
+	}
+	// JISB1 paper size.
+	JISB1 = PaperDimensions{
+		Height: 40.55,
+		Width:  28.66,
+		Unit:   IN,
+	}
+	// JISB2 paper size.
+	JISB2 = PaperDimensions{
+		Height: 28.66,
+		Width:  20.28,
+		Unit:   IN,
+	}
+	// JISB3 paper size.
+	JISB3 = PaperDimensions{
+		Height: 20.28,
+		Width:  14.33,
+		Unit:   IN,
+	}
+	// JISB4 paper size.
+	JISB4 = PaperDimensions{
+		Height: 14.33,
+		Width:  10.12,
+		Unit:   IN,
+	}
+	// JISB5 paper size.
+	JISB5 = PaperDimensions{
+		Height: 10.12,
+		Width:  7.17,
+		Unit:   IN,
+	}
+	// JISB6 paper size.
+	JISB6 = PaperDimensions{
+		Height: 7.17,
+		Width:  5.04,
+		Unit:   IN,
+	}
+	// JISB7 paper size.
+	JISB7 = PaperDimensions{
+		Height: 5.04,
+		Width:  3.58,
+		Unit:   IN,
+	}
+	// JISB8 paper size.
+	JISB8 = PaperDimensions{
+		Height: 3.58,
+		Width:  2.52,
+		Unit:   IN,
+	}
+	// JISB9 paper size.
+	JISB9 = PaperDimensions{
+		Height: 2.52,
+		Width:  1.77,
+		Unit:   IN,
+	}
+	// JISB10 paper size.
+	JISB10 = PaperDimensions{
+		Height: 1.77,
+		Width:  1.26,
+		Unit:   IN,
+	}
+	// Executive paper size.
+	Executive = PaperDimensions{
+		Height: 10.5,
+		Width:  7.25,
+		Unit:   IN,
+	}
+	// Statement (Half Letter) paper size.
+	Statement = PaperDimensions{
+		Height: 8.5,
+		Width:  5.5,
+		Unit:   IN,
+	}
+	// EnvelopeDL envelope size.
+	EnvelopeDL = PaperDimensions{
+		Height: 8.66,
+		Width:  4.33,
+		Unit:   IN,
+	}
+	// Envelope10 (US #10) envelope size.
+	Envelope10 = PaperDimensions{
+		Height: 9.5,
+		Width:  4.125,
+		Unit:   IN,
+	}
+	// Envelope9 (US #9) envelope size.
+	Envelope9 = PaperDimensions{
+		Height: 8.875,
+		Width:  3.875,
+		Unit:   IN,
+	}
+	// EnvelopeMonarch envelope size.
+	EnvelopeMonarch = PaperDimensions{
+		Height: 7.5,
+		Width:  3.875,
 		Unit:   IN,
 	}
 )
@@ -0,0 +1,182 @@
+package gotenberg
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var errUnknownUnit = errors.New("unknown unit")
+
+// Number of millimeters in one unit.
+const (
+	millimetersPerPoint      = 25.4 / 72
+	millimetersPerPixel      = 25.4 / 96
+	millimetersPerInch       = 25.4
+	millimetersPerCentimeter = 10
+	millimetersPerPica       = 25.4 / 6
+)
+
+// millimeters returns the length of one unit in millimeters. An empty unit means inches.
+func (u SizeUnit) millimeters() (float64, bool) {
+	switch u {
+	case PT:
+		return millimetersPerPoint, true
+	case PX:
+		return millimetersPerPixel, true
+	case IN, "":
+		return millimetersPerInch, true
+	case MM:
+		return 1, true
+	case CM:
+		return millimetersPerCentimeter, true
+	case PC:
+		return millimetersPerPica, true
+	default:
+		return 0, false
+	}
+}
+
+// Valid reports whether the unit is supported by Gotenberg. An empty unit is valid and means inches.
+func (u SizeUnit) Valid() bool {
+	_, ok := u.millimeters()
+
+	return ok
+}
+
+// unitRatio returns the factor which converts a value from one unit to another.
+func unitRatio(from, to SizeUnit) (float64, error) {
+	fromMillimeters, ok := from.millimeters()
+	if !ok {
+		return 0, fmt.Errorf("%w %q", errUnknownUnit, from)
+	}
+
+	toMillimeters, ok := to.millimeters()
+	if !ok {
+		return 0, fmt.Errorf("%w %q", errUnknownUnit, to)
+	}
+
+	return fromMillimeters / toMillimeters, nil
+}
+
+// Length is a distance with its unit, e.g. 12.7mm.
+type Length struct {
+	Value float64
+	Unit  SizeUnit
+}
+
+// ParseLength parses a length such as "12.7mm", "1.5cm" or "0.5 in". A number without
+// a unit is in inches, as in Gotenberg.
+func ParseLength(s string) (Length, error) {
+	trimmed := strings.TrimSpace(s)
+
+	num := strings.TrimRightFunc(trimmed, func(r rune) bool {
+		return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+	})
+	unit := SizeUnit(strings.ToLower(trimmed[len(num):]))
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+	if err != nil {
+		return Length{}, fmt.Errorf("parse length %q: invalid number", s)
+	}
+
+	if !unit.Valid() {
+		return Length{}, fmt.Errorf("parse length %q: unknown unit %q", s, unit)
+	}
+
+	if unit == "" {
+		unit = IN
+	}
+
+	return Length{Value: value, Unit: unit}, nil
+}
+
+// ToUnit converts the length to the given unit. Both units must be valid.
+func (l Length) ToUnit(unit SizeUnit) (Length, error) {
+	ratio, err := unitRatio(l.Unit, unit)
+	if err != nil {
+		return Length{}, err
+	}
+
+	return Length{Value: l.Value * ratio, Unit: unit}, nil
+}
+
+// String returns the length in the syntax accepted by Gotenberg, e.g. "12.7mm".
+func (l Length) String() string {
+	unit := l.Unit
+	if unit == "" {
+		unit = IN
+	}
+
+	return strconv.FormatFloat(l.Value, 'f', -1, 64) + string(unit)
+}
+
+// ToUnit converts the margins to the given unit. Both units must be valid.
+func (m PageMargins) ToUnit(unit SizeUnit) (PageMargins, error) {
+	ratio, err := unitRatio(m.Unit, unit)
+	if err != nil {
+		return PageMargins{}, err
+	}
+
+	return PageMargins{
+		Top:    m.Top * ratio,
+		Bottom: m.Bottom * ratio,
+		Left:   m.Left * ratio,
+		Right:  m.Right * ratio,
+		Unit:   unit,
+	}, nil
+}
+
+// ParseMargins parses margins written like the CSS margin shorthand: "1in" for all sides,
+// "10mm 15mm" for vertical and horizontal, "10mm 15mm 20mm" for top, horizontal and bottom,
+// or "10mm 15mm 20mm 25mm" for top, right, bottom and left. The result uses the unit of the
+// first value.
+func ParseMargins(s string) (PageMargins, error) {
+	values := strings.Fields(s)
+	if len(values) == 0 || len(values) > 4 {
+		return PageMargins{}, fmt.Errorf("parse margins %q: expected 1 to 4 values, got %d", s, len(values))
+	}
+
+	lengths := make([]float64, 0, len(values))
+	unit := SizeUnit("")
+
+	for _, value := range values {
+		l, err := ParseLength(value)
+		if err != nil {
+			return PageMargins{}, fmt.Errorf("parse margins %q: %w", s, err)
+		}
+
+		if l.Value < 0 {
+			return PageMargins{}, fmt.Errorf("parse margins %q: negative margin %s", s, value)
+		}
+
+		if unit == "" {
+			unit = l.Unit
+		}
+
+		if l, err = l.ToUnit(unit); err != nil {
+			return PageMargins{}, fmt.Errorf("parse margins %q: %w", s, err)
+		}
+
+		lengths = append(lengths, l.Value)
+	}
+
+	// Expand the shorthand to top, right, bottom and left, as CSS does.
+	switch len(lengths) {
+	case 1:
+		lengths = append(lengths, lengths[0], lengths[0], lengths[0])
+	case 2:
+		lengths = append(lengths, lengths[0], lengths[1])
+	case 3:
+		lengths = append(lengths, lengths[1])
+	}
+
+	return PageMargins{
+		Top:    lengths[0],
+		Right:  lengths[1],
+		Bottom: lengths[2],
+		Left:   lengths[3],
+		Unit:   unit,
+	}, nil
+}
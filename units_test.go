@@ -0,0 +1,123 @@
+package gotenberg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLength(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Length
+	}{
+		{input: "12.7mm", want: Length{Value: 12.7, Unit: MM}},
+		{input: "1.5cm", want: Length{Value: 1.5, Unit: CM}},
+		{input: " 0.5 IN ", want: Length{Value: 0.5, Unit: IN}},
+		{input: "2", want: Length{Value: 2, Unit: IN}},
+		{input: "8.270000in", want: Length{Value: 8.27, Unit: IN}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseLength(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, input := range []string{"", "mm", "12ft", "1.2.3cm"} {
+		_, err := ParseLength(input)
+		require.Error(t, err, input)
+	}
+}
+
+func TestLengthToUnit(t *testing.T) {
+	l := Length{Value: 1, Unit: IN}
+
+	for unit, want := range map[SizeUnit]float64{MM: 25.4, CM: 2.54, PT: 72, PX: 96, PC: 6, IN: 1} {
+		got, err := l.ToUnit(unit)
+		require.NoError(t, err)
+		assert.InDelta(t, want, got.Value, 1e-9, unit)
+		assert.Equal(t, unit, got.Unit)
+
+		back, err := got.ToUnit(IN)
+		require.NoError(t, err)
+		assert.InDelta(t, 1, back.Value, 1e-9, unit)
+	}
+
+	_, err := Length{Value: 1, Unit: "xx"}.ToUnit(MM)
+	require.ErrorIs(t, err, errUnknownUnit)
+	_, err = l.ToUnit("xx")
+	require.ErrorIs(t, err, errUnknownUnit)
+	_, err = NormalMargins.ToUnit("xx")
+	require.ErrorIs(t, err, errUnknownUnit)
+	_, err = PaperDimensions{Width: 1, Height: 1, Unit: "xx"}.ToUnit(IN)
+	require.ErrorIs(t, err, errUnknownUnit)
+
+	assert.Equal(t, "12.7mm", Length{Value: 12.7, Unit: MM}.String())
+}
+
+func TestParsePaperSize(t *testing.T) {
+	size, err := ParsePaperSize("a4")
+	require.NoError(t, err)
+	assert.Equal(t, A4, size)
+
+	size, err = ParsePaperSize("JIS B5")
+	require.NoError(t, err)
+	assert.Equal(t, JISB5, size)
+
+	size, err = ParsePaperSize("A5 landscape")
+	require.NoError(t, err)
+	assert.Equal(t, PaperDimensions{Width: 8.3, Height: 5.8, Unit: IN}, size)
+
+	size, err = ParsePaperSize("210x297mm")
+	require.NoError(t, err)
+	assert.Equal(t, PaperDimensions{Width: 210, Height: 297, Unit: MM}, size)
+
+	size, err = ParsePaperSize("2.54cm x 10mm")
+	require.NoError(t, err)
+	assert.InDelta(t, 25.4, size.Width, 1e-9)
+	assert.Equal(t, MM, size.Unit)
+
+	_, err = ParsePaperSize("A11")
+	require.Error(t, err)
+
+	assert.Contains(t, PaperSizeNames(), "letter")
+}
+
+func TestPaperOrientation(t *testing.T) {
+	assert.Equal(t, Ledger, Tabloid.Landscape())
+	assert.Equal(t, Tabloid, Ledger.Portrait())
+	assert.True(t, Ledger.IsLandscape())
+	assert.Equal(t, A4, A4.Portrait())
+
+	mm, err := Letter.ToUnit(MM)
+	require.NoError(t, err)
+	assert.InDelta(t, 215.9, mm.Width, 1e-9)
+	assert.InDelta(t, 279.4, mm.Height, 1e-9)
+}
+
+func TestParseMargins(t *testing.T) {
+	margins, err := ParseMargins("1in")
+	require.NoError(t, err)
+	assert.Equal(t, NormalMargins, margins)
+
+	margins, err = ParseMargins("10mm 2cm 30mm")
+	require.NoError(t, err)
+	assert.Equal(t, PageMargins{Top: 10, Right: 20, Bottom: 30, Left: 20, Unit: MM}, margins)
+
+	margins, err = ParseMargins("1 2 3 4")
+	require.NoError(t, err)
+	assert.Equal(t, PageMargins{Top: 1, Right: 2, Bottom: 3, Left: 4, Unit: IN}, margins)
+
+	_, err = ParseMargins("1 2 3 4 5")
+	require.Error(t, err)
+	_, err = ParseMargins("-1mm")
+	require.Error(t, err)
+
+	mm, err := NormalMargins.ToUnit(MM)
+	require.NoError(t, err)
+	assert.InDelta(t, 25.4, mm.Left, 1e-9)
+}
@@ -40,6 +40,15 @@ func (req *URLRequest) formDocuments() map[string]document.Document {
 	return files
 }
 
+// Validate checks the request options before it is sent.
+func (req *URLRequest) Validate() error {
+	v := &validator{}
+	v.absoluteURL(string(fieldURL), req.fields[fieldURL])
+	v.validateChromium(req.chromiumRequest)
+
+	return v.err()
+}
+
 // Compile-time checks to ensure type implements desired interfaces.
 var (
 	_ = multipartRequester(new(URLRequest))
@@ -0,0 +1,293 @@
+package gotenberg
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+const (
+	minScale = 0.1
+	maxScale = 2.0
+
+	minOfficeQuality = 1
+	maxOfficeQuality = 100
+
+	minScreenshotQuality = 0
+	maxScreenshotQuality = 100
+)
+
+// FieldError describes a single invalid option of a request.
+type FieldError struct {
+	// Field is the name of the form field, header or document the problem relates to.
+	Field  string
+	Reason string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Reason)
+}
+
+// ValidationError lists all problems found while validating a request.
+type ValidationError struct {
+	Errors []error
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+
+	return "invalid request: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap allows errors.Is and errors.As to inspect every problem.
+func (e *ValidationError) Unwrap() []error {
+	return e.Errors
+}
+
+// validator accumulates problems so that all of them are reported at once.
+type validator struct {
+	errs []error
+	// filenames holds the filenames of the documents seen so far, as Gotenberg requires them to be unique.
+	filenames map[string]bool
+}
+
+func (v *validator) addf(field, format string, args ...any) {
+	v.errs = append(v.errs, &FieldError{Field: field, Reason: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Errors: v.errs}
+}
+
+func (v *validator) validateBase(br *baseRequest) {
+	hookURL, hasHookURL := br.headers[headerWebhookURL]
+	errorURL, hasErrorURL := br.headers[headerWebhookErrorURL]
+
+	if hasHookURL || hasErrorURL {
+		v.absoluteURL(string(headerWebhookURL), hookURL)
+		v.absoluteURL(string(headerWebhookErrorURL), errorURL)
+	}
+
+	if eventsURL, ok := br.headers[headerWebhookEventsURL]; ok {
+		v.absoluteURL(string(headerWebhookEventsURL), eventsURL)
+	}
+
+	if raw, ok := br.fields[fieldDownloadFrom]; ok {
+		var dfs []downloadFrom
+		if err := json.Unmarshal([]byte(raw), &dfs); err != nil {
+			v.addf(string(fieldDownloadFrom), "invalid JSON: %v", err)
+		}
+
+		for _, df := range dfs {
+			v.absoluteURL(string(fieldDownloadFrom), df.URL)
+		}
+	}
+
+	if raw, ok := br.fields[fieldMetadata]; ok && !json.Valid([]byte(raw)) {
+		v.addf(string(fieldMetadata), "must be valid JSON")
+	}
+}
+
+func (v *validator) validateChromium(req *chromiumRequest) {
+	v.validateBase(req.baseRequest)
+
+	if req.header != nil {
+		v.document("header.html", req.header, ".html")
+	}
+
+	if req.footer != nil {
+		v.document("footer.html", req.footer, ".html")
+	}
+
+	if raw, ok := req.fields[fieldChromiumWaitDelay]; ok {
+		if d, err := time.ParseDuration(raw); err != nil || d < 0 {
+			v.addf(string(fieldChromiumWaitDelay), "must be a non-negative duration, got %q", raw)
+		}
+	}
+
+	if raw, ok := req.fields[fieldChromiumScale]; ok {
+		if scale, err := strconv.ParseFloat(raw, 64); err != nil || scale < minScale || scale > maxScale {
+			v.addf(string(fieldChromiumScale), "must be between %.1f and %.1f, got %s", minScale, maxScale, raw)
+		}
+	}
+
+	for _, field := range []formField{fieldChromiumPaperWidth, fieldChromiumPaperHeight} {
+		if raw, ok := req.fields[field]; ok {
+			if size, err := ParseLength(raw); err != nil || size.Value <= 0 {
+				v.addf(string(field), "must be a positive size, got %q", raw)
+			}
+		}
+	}
+
+	for _, field := range []formField{
+		fieldChromiumMarginTop, fieldChromiumMarginBottom, fieldChromiumMarginLeft, fieldChromiumMarginRight,
+	} {
+		if raw, ok := req.fields[field]; ok {
+			if size, err := ParseLength(raw); err != nil || size.Value < 0 {
+				v.addf(string(field), "must be a non-negative size, got %q", raw)
+			}
+		}
+	}
+
+	if raw, ok := req.fields[fieldChromiumNativePageRanges]; ok {
+		v.pageRanges(string(fieldChromiumNativePageRanges), raw)
+	}
+
+	if raw, ok := req.fields[fieldOfficePdfA]; ok {
+		v.pdfA(string(fieldOfficePdfA), raw)
+	}
+
+	v.validateScreenshot(req)
+}
+
+func (v *validator) validateScreenshot(req *chromiumRequest) {
+	for _, field := range []formField{fieldScreenshotWidth, fieldScreenshotHeight} {
+		if raw, ok := req.fields[field]; ok {
+			if size, err := strconv.ParseFloat(raw, 64); err != nil || size <= 0 {
+				v.addf(string(field), "must be a positive number of pixels, got %s", raw)
+			}
+		}
+	}
+
+	format := PNG
+	if raw, ok := req.fields[fieldScreenshotFormat]; ok {
+		format = ImageFormat(raw)
+		if format != PNG && format != JPEG && format != WebP {
+			v.addf(string(fieldScreenshotFormat), "must be one of %s, %s or %s, got %q", PNG, JPEG, WebP, raw)
+		}
+	}
+
+	if rect := req.clipRect; rect != nil {
+		if rect.Empty() || rect.Min.X < 0 || rect.Min.Y < 0 {
+			v.addf("clipRect", "must be a non-empty rectangle of positive coordinates, got %v", *rect)
+		}
+
+		if format == WebP {
+			v.addf("clipRect", "is not supported for the %s format", WebP)
+		}
+	}
+
+	if raw, ok := req.fields[fieldScreenshotQuality]; ok {
+		if format != JPEG {
+			v.addf(string(fieldScreenshotQuality), "is only supported for the %s format, got %s", JPEG, format)
+		}
+
+		if quality, err := strconv.Atoi(raw); err != nil ||
+			quality < minScreenshotQuality || quality > maxScreenshotQuality {
+			v.addf(string(fieldScreenshotQuality), "must be between %d and %d, got %s",
+				minScreenshotQuality, maxScreenshotQuality, raw)
+		}
+	}
+}
+
+func (v *validator) absoluteURL(field, raw string) {
+	if raw == "" {
+		v.addf(field, "must not be empty")
+
+		return
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		v.addf(field, "must be an absolute URL, got %q", raw)
+	}
+}
+
+func (v *validator) pdfA(field, raw string) {
+	switch PdfAFormat(raw) {
+	case PdfA1b, PdfA2b, PdfA3b:
+	default:
+		v.addf(field, "must be one of %s, %s or %s, got %q", PdfA1b, PdfA2b, PdfA3b, raw)
+	}
+}
+
+func (v *validator) pageRanges(field, raw string) {
+	if _, err := ParsePageRanges(raw); err != nil {
+		v.addf(field, "%v", err)
+	}
+}
+
+// requiredDocuments returns how many documents the request must upload, none if Gotenberg downloads
+// its files with DownloadFrom.
+func (br *baseRequest) requiredDocuments(n int) int {
+	if _, ok := br.fields[fieldDownloadFrom]; ok {
+		return 0
+	}
+
+	return n
+}
+
+func (v *validator) documents(field string, docs []document.Document, minDocs int, exts ...string) {
+	if len(docs) < minDocs {
+		v.addf(field, "at least %d document(s) required, got %d", minDocs, len(docs))
+	}
+
+	for i, doc := range docs {
+		if doc == nil {
+			v.addf(field, "document %d is nil", i)
+
+			continue
+		}
+
+		v.extension(doc, exts...)
+	}
+}
+
+func (v *validator) document(field string, doc document.Document, exts ...string) {
+	if doc == nil {
+		v.addf(field, "document is required")
+
+		return
+	}
+
+	v.extension(doc, exts...)
+}
+
+func (v *validator) extension(doc document.Document, exts ...string) {
+	if doc.Filename() == "" {
+		v.addf("files", "document has an empty filename")
+
+		return
+	}
+
+	// The filename of a document fetched by the client may change, e.g. to the one the server replies with.
+	// The client validates the request again once it is known.
+	if !document.FilenameResolved(doc) {
+		return
+	}
+
+	if v.filenames == nil {
+		v.filenames = make(map[string]bool)
+	}
+
+	if v.filenames[doc.Filename()] {
+		v.addf(doc.Filename(), "several documents have the same filename")
+	}
+	v.filenames[doc.Filename()] = true
+
+	if len(exts) == 0 {
+		return
+	}
+
+	ext := strings.ToLower(filepath.Ext(doc.Filename()))
+	for _, allowed := range exts {
+		if ext == allowed {
+			return
+		}
+	}
+
+	v.addf(doc.Filename(), "must have one of the extensions %s", strings.Join(exts, ", "))
+}
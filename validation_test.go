@@ -0,0 +1,155 @@
+package gotenberg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestValidateHTML(t *testing.T) {
+	index, err := document.FromString("index.html", "<html>Foo</html>")
+	require.NoError(t, err)
+
+	req := NewHTMLRequest(index)
+	req.Scale(1)
+	req.NativePageRanges("1-5, 8, 11-13")
+	req.Format(JPEG)
+	req.ScreenshotQuality(50)
+	require.NoError(t, req.Validate())
+
+	req = NewHTMLRequest(nil)
+	req.Scale(3)
+	req.NativePageRanges("5-1")
+	req.ScreenshotQuality(50)
+
+	err = req.Validate()
+	require.Error(t, err)
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Len(t, verr.Errors, 4)
+	assert.ErrorContains(t, err, "index.html")
+	assert.ErrorContains(t, err, "scale")
+	assert.ErrorContains(t, err, "nativePageRanges")
+	assert.ErrorContains(t, err, "quality")
+
+	header, err := document.FromString("header.txt", "Foo")
+	require.NoError(t, err)
+
+	req = NewHTMLRequest(index)
+	req.Header(header)
+	require.ErrorContains(t, req.Validate(), "header.txt: must have one of the extensions .html")
+}
+
+func TestValidateLibreOffice(t *testing.T) {
+	doc, err := document.FromString("document.txt", "Foo")
+	require.NoError(t, err)
+
+	req := NewLibreOfficeRequest(doc)
+	req.Quality(100)
+	req.MaxImageResolution(300)
+	require.NoError(t, req.Validate())
+
+	req.Quality(0)
+	req.MaxImageResolution(100)
+	req.NativePageRanges("1-a")
+
+	var verr *ValidationError
+	require.ErrorAs(t, req.Validate(), &verr)
+	assert.Len(t, verr.Errors, 3)
+
+	var ferr *FieldError
+	require.ErrorAs(t, verr, &ferr)
+	assert.Equal(t, "quality", ferr.Field)
+
+	require.Error(t, NewLibreOfficeRequest().Validate())
+}
+
+func TestValidatePdfEngines(t *testing.T) {
+	pdf, err := document.FromString("foo.pdf", "%PDF-")
+	require.NoError(t, err)
+	txt, err := document.FromString("foo.txt", "Foo")
+	require.NoError(t, err)
+
+	require.NoError(t, NewMergeRequest(pdf).Validate())
+	require.Error(t, NewMergeRequest(pdf, pdf).Validate())
+	require.Error(t, NewMergeRequest(txt).Validate())
+
+	split := NewSplitIntervalsRequest(pdf)
+	require.Error(t, split.Validate())
+	split.SplitSpan(2)
+	require.NoError(t, split.Validate())
+
+	pages := NewSplitPagesRequest(pdf)
+	pages.SplitSpan("1-2,")
+	require.Error(t, pages.Validate())
+
+	// The extension of a fetched document is only known once the client fetches it.
+	remote, err := document.FromURL(context.Background(), "https://example.com/files/123", nil)
+	require.NoError(t, err)
+	require.NoError(t, NewMergeRequest(remote).Validate())
+
+	write := NewWriteMetadataRequest(pdf)
+	require.Error(t, write.Validate())
+	write.Metadata([]byte(`{"Author":"Foo"}`))
+	require.NoError(t, write.Validate())
+
+	write = NewWriteMetadataRequest(txt)
+	write.Metadata([]byte(`{"Author":"Foo"}`))
+	require.Error(t, write.Validate())
+}
+
+func TestValidateDownloadFrom(t *testing.T) {
+	downloads := map[string]map[string]string{"https://example.com/foo.pdf": nil}
+
+	merge := NewMergeRequest()
+	require.Error(t, merge.Validate())
+	merge.DownloadFrom(downloads)
+	require.NoError(t, merge.Validate())
+
+	office := NewLibreOfficeRequest()
+	office.DownloadFrom(downloads)
+	require.NoError(t, office.Validate())
+
+	read := NewReadMetadataRequest()
+	read.DownloadFrom(downloads)
+	require.NoError(t, read.Validate())
+}
+
+func TestValidateWebhook(t *testing.T) {
+	req := NewURLRequest("https://example.com")
+	req.UseWebhook("https://example.com/success", "")
+
+	var ferr *FieldError
+	require.ErrorAs(t, req.Validate(), &ferr)
+	assert.Equal(t, string(headerWebhookErrorURL), ferr.Field)
+
+	require.Error(t, NewURLRequest("example.com").Validate())
+
+	req = NewURLRequest("https://example.com")
+	req.UseWebhookEvents("/events")
+	require.ErrorAs(t, req.Validate(), &ferr)
+	assert.Equal(t, string(headerWebhookEventsURL), ferr.Field)
+}
+
+func TestClientValidatesBeforeSending(t *testing.T) {
+	c, err := NewClient("http://localhost:0", nil)
+	require.NoError(t, err)
+
+	req := NewURLRequest("")
+	_, err = c.Send(context.Background(), req)
+	require.Error(t, err)
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+
+	c, err = NewClient("http://localhost:0", nil, WithoutValidation())
+	require.NoError(t, err)
+
+	_, err = c.Send(context.Background(), req)
+	require.ErrorIs(t, err, errSendRequestFailed)
+}
@@ -0,0 +1,370 @@
+package gotenberg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+var errInvalidWebhookCall = errors.New("invalid webhook call")
+
+const (
+	defaultWebhookBodyLimit      = 512 << 20
+	defaultWebhookErrorBodyLimit = 1 << 20
+
+	// webhookKindParam marks the calls of the error webhook, see WebhookErrorURL.
+	webhookKindParam  = "gotenberg-webhook"
+	webhookKindError  = "error"
+	webhookKindEvents = "events"
+	// webhookKindJSON is an unmarked call with a JSON body, i.e. an error or an event.
+	webhookKindJSON = "json"
+)
+
+// WebhookResult is a resulting file sent by Gotenberg to the webhook URL.
+type WebhookResult struct {
+	// Filename is the output filename, with its extension, taken from the Content-Disposition header.
+	Filename string
+	// ContentType is the MIME type of the file.
+	ContentType string
+	// Trace identifies the request in Gotenberg's logs.
+	Trace string
+	// Header holds all headers of the call, including those set with SetWebhookExtraHeaders.
+	Header http.Header
+	// Body is the content of the file. It is only valid until the callback returns.
+	Body io.Reader
+}
+
+// WebhookError is an error sent by Gotenberg to the error webhook URL.
+type WebhookError struct {
+	// Status is the HTTP status code Gotenberg would have replied with.
+	Status int `json:"status"`
+	// Message describes the error.
+	Message string `json:"message"`
+	// Trace identifies the request in Gotenberg's logs.
+	Trace string `json:"-"`
+	// Header holds all headers of the call, including those set with SetWebhookExtraHeaders.
+	Header http.Header `json:"-"`
+}
+
+func (e WebhookError) Error() string {
+	return fmt.Sprintf("gotenberg error %d: %s", e.Status, e.Message)
+}
+
+// WebhookEventType is the stage of a request reported by a WebhookEvent.
+type WebhookEventType string
+
+const (
+	WebhookEventStarted   WebhookEventType = "started"
+	WebhookEventCompleted WebhookEventType = "completed"
+	WebhookEventFailed    WebhookEventType = "failed"
+)
+
+// WebhookEvent is a lifecycle event sent by Gotenberg to the events URL set with UseWebhookEvents.
+type WebhookEvent struct {
+	// Type is the stage the request reached. Unknown types sent by newer Gotenberg versions are kept as is.
+	Type WebhookEventType `json:"type"`
+	// Time is when the event occurred.
+	Time time.Time `json:"timestamp"`
+	// Status and Message describe the error of a failed event.
+	Status  int    `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Trace identifies the request in Gotenberg's logs.
+	Trace string `json:"-"`
+	// Header holds all headers of the call, including those set with SetWebhookExtraHeaders.
+	Header http.Header `json:"-"`
+}
+
+// WebhookErrorURL returns the URL to pass as the error URL of UseWebhook, so that a WebhookHandler
+// serving both URLs can tell errors from results without looking at their content.
+func WebhookErrorURL(hookURL string) (string, error) {
+	return webhookKindURL(hookURL, webhookKindError)
+}
+
+// WebhookEventsURL returns the URL to pass to UseWebhookEvents, so that a WebhookHandler serving
+// the webhook URL also receives events.
+func WebhookEventsURL(hookURL string) (string, error) {
+	return webhookKindURL(hookURL, webhookKindEvents)
+}
+
+func webhookKindURL(hookURL, kind string) (string, error) {
+	u, err := url.Parse(hookURL)
+	if err != nil {
+		return "", fmt.Errorf("parsing webhook URL %s: %w", hookURL, err)
+	}
+
+	query := u.Query()
+	query.Set(webhookKindParam, kind)
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// WebhookHandler is an http.Handler which receives the calls made by Gotenberg to the webhook URLs.
+// Calls to a URL built with WebhookErrorURL are passed to the error callback, and calls to a URL built
+// with WebhookEventsURL to the event callback. Calls to other URLs are told apart by their content:
+// a JSON body without a Content-Disposition header is an event if it has a type, an error otherwise,
+// and anything else is a result.
+//
+// A callback returning an error makes the handler reply with 500 Internal Server Error, so that Gotenberg
+// retries the call. A body larger than the limit is rejected with 413 Request Entity Too Large.
+type WebhookHandler struct {
+	onSuccess func(ctx context.Context, res WebhookResult) error
+	onError   func(ctx context.Context, werr WebhookError) error
+	onEvent   func(ctx context.Context, event WebhookEvent) error
+
+	bodyLimit      int64
+	errorBodyLimit int64
+
+	signer *webhookSigner
+	nonces nonceCache
+}
+
+// NewWebhookHandler creates a handler which passes results to onSuccess and errors to onError.
+// Results are limited to 512 MiB and errors to 1 MiB.
+func NewWebhookHandler(
+	onSuccess func(ctx context.Context, res WebhookResult) error,
+	onError func(ctx context.Context, werr WebhookError) error,
+) *WebhookHandler {
+	return &WebhookHandler{
+		onSuccess:      onSuccess,
+		onError:        onError,
+		bodyLimit:      defaultWebhookBodyLimit,
+		errorBodyLimit: defaultWebhookErrorBodyLimit,
+	}
+}
+
+// BodyLimit sets the maximum size of a resulting file, in bytes.
+func (wh *WebhookHandler) BodyLimit(bytes int64) {
+	wh.bodyLimit = bytes
+}
+
+// ErrorBodyLimit sets the maximum size of an error or an event, in bytes.
+func (wh *WebhookHandler) ErrorBodyLimit(bytes int64) {
+	wh.errorBodyLimit = bytes
+}
+
+// OnEvent sets the callback which receives the calls to a URL built with WebhookEventsURL.
+// Without it, events are acknowledged and ignored.
+func (wh *WebhookHandler) OnEvent(onEvent func(ctx context.Context, event WebhookEvent) error) {
+	wh.onEvent = onEvent
+}
+
+// VerifySignature makes the handler reject, with 401 Unauthorized, the calls whose URL was not signed
+// with key by a client created with WithWebhookSigning, whose signature expired, or which were already
+// handled successfully. As all the events of a request are sent to the same URL, an event is only
+// rejected as a replay if an event of the same type was already handled.
+func (wh *WebhookHandler) VerifySignature(key []byte) {
+	wh.signer = newWebhookSigner(key, 0)
+}
+
+func (wh *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	call := &webhookCall{}
+	if wh.signer != nil {
+		var err error
+		if call.nonce, call.expires, err = wh.signer.verify(r.URL, time.Now()); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		call.nonces = &wh.nonces
+	}
+
+	kind := r.URL.Query().Get(webhookKindParam)
+	if kind == "" {
+		kind = webhookKindOf(r)
+	}
+
+	var err error
+	switch kind {
+	case webhookKindError, webhookKindEvents, webhookKindJSON:
+		err = wh.serveJSON(w, r, call, kind)
+	default:
+		err = wh.serveSuccess(w, r, call)
+	}
+
+	// Gotenberg retries failed calls with the same URL.
+	if err != nil {
+		call.release()
+	}
+
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, errWebhookReplayed):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, errInvalidWebhookCall):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// webhookCall holds the nonce of a signed call, which is reserved once the call is identified.
+type webhookCall struct {
+	// nonces is nil for unsigned calls.
+	nonces   *nonceCache
+	nonce    string
+	expires  time.Time
+	reserved string
+}
+
+// reserve records the nonce of the call, unless it was already used. The id tells apart the calls
+// Gotenberg makes with the same URL, i.e. the events of a request, which share one signature.
+func (c *webhookCall) reserve(id string) error {
+	if c.nonces == nil {
+		return nil
+	}
+
+	key := c.nonce
+	if id != "" {
+		key += "/" + id
+	}
+
+	if !c.nonces.reserve(key, c.expires, time.Now()) {
+		return errWebhookReplayed
+	}
+
+	c.reserved = key
+
+	return nil
+}
+
+// release forgets the nonce reserved by the call, if any.
+func (c *webhookCall) release() {
+	if c.reserved != "" {
+		c.nonces.release(c.reserved)
+	}
+}
+
+func (wh *WebhookHandler) serveSuccess(w http.ResponseWriter, r *http.Request, call *webhookCall) error {
+	if err := call.reserve(""); err != nil {
+		return err
+	}
+
+	if r.ContentLength > wh.bodyLimit {
+		return &http.MaxBytesError{Limit: wh.bodyLimit}
+	}
+
+	body := http.MaxBytesReader(w, r.Body, wh.bodyLimit)
+
+	res := WebhookResult{
+		Filename:    document.DispositionFilename(r.Header.Get("Content-Disposition")),
+		ContentType: r.Header.Get("Content-Type"),
+		Trace:       r.Header.Get(string(headerTrace)),
+		Header:      r.Header,
+		Body:        body,
+	}
+
+	if wh.onSuccess == nil {
+		_, err := io.Copy(io.Discard, body)
+
+		return err
+	}
+
+	return wh.onSuccess(r.Context(), res)
+}
+
+// webhookKindOf tells an unmarked call with a JSON body, which Gotenberg sends for errors and events,
+// from a result. Results always come with a Content-Disposition header, even JSON ones.
+func webhookKindOf(r *http.Request) string {
+	if r.Header.Get("Content-Disposition") != "" {
+		return ""
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return ""
+	}
+
+	return webhookKindJSON
+}
+
+// serveJSON handles an error or an event. For unmarked calls, the kind is taken from the body.
+func (wh *WebhookHandler) serveJSON(w http.ResponseWriter, r *http.Request, call *webhookCall, kind string) error {
+	if r.ContentLength > wh.errorBodyLimit {
+		return &http.MaxBytesError{Limit: wh.errorBodyLimit}
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, wh.errorBodyLimit))
+	if err != nil {
+		return err
+	}
+
+	if kind == webhookKindJSON {
+		var probe struct {
+			Type string `json:"type"`
+		}
+		if err = json.Unmarshal(data, &probe); err != nil {
+			return fmt.Errorf("%w: decoding body: %w", errInvalidWebhookCall, err)
+		}
+
+		kind = webhookKindError
+		if probe.Type != "" {
+			kind = webhookKindEvents
+		}
+	}
+
+	if kind == webhookKindEvents {
+		return wh.handleEvent(r, call, data)
+	}
+
+	return wh.handleError(r, call, data)
+}
+
+func (wh *WebhookHandler) handleError(r *http.Request, call *webhookCall, data []byte) error {
+	if err := call.reserve(""); err != nil {
+		return err
+	}
+
+	var werr WebhookError
+	if err := json.Unmarshal(data, &werr); err != nil {
+		return fmt.Errorf("%w: decoding error: %w", errInvalidWebhookCall, err)
+	}
+
+	werr.Trace = r.Header.Get(string(headerTrace))
+	werr.Header = r.Header
+
+	if wh.onError == nil {
+		return nil
+	}
+
+	return wh.onError(r.Context(), werr)
+}
+
+func (wh *WebhookHandler) handleEvent(r *http.Request, call *webhookCall, data []byte) error {
+	var event WebhookEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return fmt.Errorf("%w: decoding event: %w", errInvalidWebhookCall, err)
+	}
+
+	if err := call.reserve(string(event.Type)); err != nil {
+		return err
+	}
+
+	event.Trace = r.Header.Get(string(headerTrace))
+	event.Header = r.Header
+
+	if wh.onEvent == nil {
+		return nil
+	}
+
+	return wh.onEvent(r.Context(), event)
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = http.Handler(new(WebhookHandler))
+	_ = error(WebhookError{})
+)
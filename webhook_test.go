@@ -0,0 +1,183 @@
+package gotenberg
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookHandlerSuccess(t *testing.T) {
+	var got WebhookResult
+	var body string
+	wh := NewWebhookHandler(func(_ context.Context, res WebhookResult) error {
+		got = res
+		data, err := io.ReadAll(res.Body)
+		body = string(data)
+
+		return err
+	}, nil)
+
+	r := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader("%PDF-result"))
+	r.Header.Set("Content-Type", "application/pdf")
+	r.Header.Set("Content-Disposition", `attachment; filename="../report.pdf"`)
+	r.Header.Set("Gotenberg-Trace", "trace-1")
+	r.Header.Set("X-Job", "42")
+	w := httptest.NewRecorder()
+
+	wh.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "report.pdf", got.Filename)
+	assert.Equal(t, "application/pdf", got.ContentType)
+	assert.Equal(t, "trace-1", got.Trace)
+	assert.Equal(t, "42", got.Header.Get("X-Job"))
+	assert.Equal(t, "%PDF-result", body)
+}
+
+func TestWebhookHandlerError(t *testing.T) {
+	errorURL, err := WebhookErrorURL("https://example.com/hook?job=42")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/hook?gotenberg-webhook=error&job=42", errorURL)
+
+	var got WebhookError
+	wh := NewWebhookHandler(nil, func(_ context.Context, werr WebhookError) error {
+		got = werr
+
+		return nil
+	})
+
+	r := httptest.NewRequest(http.MethodPost, errorURL, strings.NewReader(`{"status":400,"message":"Invalid form data"}`))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Gotenberg-Trace", "trace-2")
+	w := httptest.NewRecorder()
+
+	wh.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, 400, got.Status)
+	assert.Equal(t, "Invalid form data", got.Message)
+	assert.Equal(t, "trace-2", got.Trace)
+	assert.EqualError(t, got, "gotenberg error 400: Invalid form data")
+
+	r = httptest.NewRequest(http.MethodPost, errorURL, strings.NewReader("not json"))
+	w = httptest.NewRecorder()
+	wh.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestWebhookHandlerLimits(t *testing.T) {
+	wh := NewWebhookHandler(func(_ context.Context, res WebhookResult) error {
+		_, err := io.Copy(io.Discard, res.Body)
+
+		return err
+	}, nil)
+	wh.BodyLimit(4)
+
+	r := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader("%PDF-result"))
+	w := httptest.NewRecorder()
+	wh.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	// Without a Content-Length, the limit is enforced while the body is read.
+	r = httptest.NewRequest(http.MethodPost, "/hook", io.MultiReader(strings.NewReader("%PDF-result")))
+	r.ContentLength = -1
+	w = httptest.NewRecorder()
+	wh.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	wh = NewWebhookHandler(func(context.Context, WebhookResult) error {
+		return errors.New("storage unavailable")
+	}, nil)
+	r = httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader("%PDF-result"))
+	w = httptest.NewRecorder()
+	wh.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestWebhookHandlerEvent(t *testing.T) {
+	eventsURL, err := WebhookEventsURL("https://example.com/hook")
+	require.NoError(t, err)
+
+	var got []WebhookEvent
+	wh := NewWebhookHandler(nil, nil)
+	wh.OnEvent(func(_ context.Context, event WebhookEvent) error {
+		got = append(got, event)
+
+		return nil
+	})
+
+	for _, body := range []string{
+		`{"type":"started","timestamp":"2024-05-01T10:00:00Z"}`,
+		`{"type":"failed","timestamp":"2024-05-01T10:00:05Z","status":503,"message":"timeout"}`,
+	} {
+		r := httptest.NewRequest(http.MethodPost, eventsURL, strings.NewReader(body))
+		r.Header.Set("Gotenberg-Trace", "trace-3")
+		w := httptest.NewRecorder()
+		wh.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	}
+
+	require.Len(t, got, 2)
+	assert.Equal(t, WebhookEventStarted, got[0].Type)
+	assert.Equal(t, "trace-3", got[0].Trace)
+	assert.Equal(t, WebhookEventFailed, got[1].Type)
+	assert.Equal(t, 503, got[1].Status)
+	assert.Equal(t, "timeout", got[1].Message)
+	assert.Equal(t, 5*time.Second, got[1].Time.Sub(got[0].Time))
+}
+
+func TestWebhookHandlerUnmarked(t *testing.T) {
+	var (
+		results []WebhookResult
+		errs    []WebhookError
+		events  []WebhookEvent
+	)
+
+	wh := NewWebhookHandler(func(_ context.Context, res WebhookResult) error {
+		results = append(results, res)
+
+		return nil
+	}, func(_ context.Context, werr WebhookError) error {
+		errs = append(errs, werr)
+
+		return nil
+	})
+	wh.OnEvent(func(_ context.Context, event WebhookEvent) error {
+		events = append(events, event)
+
+		return nil
+	})
+
+	call := func(contentType, disposition, body string) int {
+		r := httptest.NewRequest(http.MethodPost, "https://example.com/error", strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		if disposition != "" {
+			r.Header.Set("Content-Disposition", disposition)
+		}
+		w := httptest.NewRecorder()
+		wh.ServeHTTP(w, r)
+
+		return w.Code
+	}
+
+	// URLs set without WebhookErrorURL or WebhookEventsURL are told apart by their content.
+	assert.Equal(t, http.StatusNoContent, call("application/json; charset=utf-8", "", `{"status":400,"message":"bad"}`))
+	assert.Equal(t, http.StatusNoContent, call("application/json", "", `{"type":"started"}`))
+	assert.Equal(t, http.StatusNoContent, call("application/json", `attachment; filename="metadata.json"`, `{"foo.pdf":{}}`))
+	assert.Equal(t, http.StatusBadRequest, call("application/json", "", "not json"))
+
+	require.Len(t, errs, 1)
+	assert.Equal(t, 400, errs[0].Status)
+	require.Len(t, events, 1)
+	assert.Equal(t, WebhookEventStarted, events[0].Type)
+	require.Len(t, results, 1)
+	assert.Equal(t, "metadata.json", results[0].Filename)
+}